@@ -0,0 +1,15 @@
+ephemeral "sym_token" "job" {
+  bot_id = sym_bot.this.id
+  expiry = "1h"
+  label  = "Nightly access review"
+
+  # The token is used by the job after the Terraform run, so it is not revoked when the run ends.
+  revoke_on_close = false
+}
+
+# The token is only available during the Terraform run, and is never stored in the plan or state.
+resource "aws_secretsmanager_secret_version" "job_token" {
+  secret_id                = aws_secretsmanager_secret.job_token.id
+  secret_string_wo         = ephemeral.sym_token.job.token
+  secret_string_wo_version = 1
+}
@@ -0,0 +1,3 @@
+output "flow_srn" {
+  value = provider::sym::build_srn("healthy-health", "flow", sym_flow.this.name, "latest")
+}
@@ -0,0 +1,17 @@
+resource "sym_flow" "this" {
+  name  = "approval"
+  label = "Approval"
+
+  # The helpers are included before impl.py, in the given order.
+  implementation = provider::sym::impl_bundle(
+    "${path.module}/impl.py",
+    "${path.module}/../shared/slack_helpers.py",
+    "${path.module}/../shared/approvers.py",
+  )
+
+  environment_id = sym_environment.this.id
+
+  params {
+    strategy_id = sym_strategy.this.id
+  }
+}
@@ -0,0 +1,7 @@
+locals {
+  template = provider::sym::parse_srn("sym:template:approval:1.0.0")
+}
+
+output "template_version" {
+  value = local.template.version # "1.0.0"
+}
@@ -0,0 +1,12 @@
+resource "sym_target" "this" {
+  for_each = var.permission_sets
+
+  type  = "aws_sso_permission_set"
+  name  = provider::sym::slugify("AWS ${each.key}") # e.g. "AWS Prod / Admins" becomes "aws-prod-admins"
+  label = "AWS ${each.key}"
+
+  settings = {
+    permission_set_arn = each.value
+    account_id         = var.account_id
+  }
+}
@@ -0,0 +1,3 @@
+# sym_bot can be imported using the username (aka the name attribute)
+# the resource may also be imported by its UUID
+terraform import sym_bot.this runtime-job-bot
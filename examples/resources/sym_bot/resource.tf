@@ -0,0 +1,4 @@
+resource "sym_bot" "this" {
+  name  = "runtime-job-bot"
+  label = "Runtime Job Bot"
+}
@@ -1,3 +1,4 @@
 # sym_environment can be imported using the slug (aka the name attribute)
 # you can find an environment's slug by running `symflow resources list sym_environment`
+# the resource may also be imported by its UUID
 terraform import sym_environment.sandbox sandbox
@@ -1,3 +1,4 @@
 # sym_error_logger can be imported using the slug (derived from the destination attribute)
 # you can find an error logger's slug by running `symflow resources list sym_error_logger`
+# the resource may also be imported by its UUID
 terraform import sym_error_logger.this sym-errors
@@ -1,3 +1,4 @@
 # sym_flow can be imported using the slug (aka the name attribute)
 # you can find a flow's slug by running `symflow resources list sym_flow`
+# the resource may also be imported by its UUID
 terraform import sym_flow.sso_flow sso_access_prod
@@ -40,11 +40,14 @@ resource "sym_strategy" "sso_main" {
   name = "flow-sso-main"
   label = "Flow SSO Main"
   integration_id = sym_integration.runtime_context.id
-  targets = [ sym_target.prod_break_glass.id ]
 
   settings = {
     instance_arn = "arn:aws:::instance/ssoinst-abcdefghi12314135325"
   }
+
+  target {
+    id = sym_target.prod_break_glass.id
+  }
 }
 
 resource "sym_runtime" "this" {
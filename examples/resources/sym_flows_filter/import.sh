@@ -1,3 +1,3 @@
-# sym_flows_filter can be imported using the resource name
+# sym_flows_filter can be imported using any identifier, since there is only one FlowsFilter per org
 # the following command assumes you have named the resource "this"
 terraform import sym_flows_filter.this sym_flows_filter
@@ -14,6 +14,11 @@ resource "sym_flows_filter" "this" {
   integrations = {
     pagerduty_id = sym_integration.pagerduty.id
   }
+
+  # Update an existing FlowsFilter in place instead of failing, and restore it when this resource is destroyed.
+  adopt_existing = true
+  on_destroy     = "restore"
+  owner          = terraform.workspace
 }
 
 ### implementation.py (implementation file written in python)
@@ -1,3 +1,4 @@
 # sym_integration can be imported in the format type:slug (the slug is the name attribute)
 # you can find an integration's type and slug by running `symflow resources list sym_integration`
+# the type may be omitted if the slug is unique, and the resource may also be imported by its UUID
 terraform import sym_integration.pagerduty pagerduty:prod-pagerduty
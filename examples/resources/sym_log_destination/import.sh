@@ -1,3 +1,4 @@
 # sym_log_destination can be imported in the format type:slug (the slug is the name attribute)
 # you can find a log destination's type and slug by running `symflow resources list sym_log_destination`
+# the type may be omitted if the slug is unique, and the resource may also be imported by its UUID
 terraform import sym_log_destination.firehose kinesis_firehose:my_stream_name
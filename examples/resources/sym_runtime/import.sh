@@ -1,3 +1,4 @@
 # sym_runtime can be imported using the slug (aka the name attribute)
 # you can find a runtime's slug by running `symflow resources list sym_runtime`
+# the resource may also be imported by its UUID
 terraform import sym_runtime.this prod_runtime
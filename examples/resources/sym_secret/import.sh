@@ -1,3 +1,4 @@
-# sym_secret can be imported using the slug (derived from the path attribute)
-# you can find a secret's slug by running `symflow resources list sym_secret`
-terraform import sym_secret.okta_api_key okta_api_key
+# sym_secret can be imported in the format source:path, where source is the name or ID of its sym_secrets resource
+# you can find a secret's source and path by running `symflow resources list sym_secret`
+# the source may be omitted if the path is unique, and the resource may also be imported by its UUID
+terraform import sym_secret.okta_api_key prod:/sym/okta_api_key
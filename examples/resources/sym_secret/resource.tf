@@ -34,3 +34,13 @@ resource "sym_secret" "github_api_key" {
   path      = aws_secretsmanager_secret.github_api_key.name
   source_id = sym_secrets.this.id
 }
+
+# With Terraform 1.11 or later, the provider can also store the value of the secret in its source.
+# The value is never stored in the Terraform plan or state, and is only sent again when value_wo_version changes.
+resource "sym_secret" "pagerduty_api_key" {
+  path      = "pagerduty-strategy/pagerduty-api-key"
+  source_id = sym_secrets.this.id
+
+  value_wo         = var.pagerduty_api_key
+  value_wo_version = 1
+}
@@ -1,3 +1,4 @@
 # sym_secrets can be imported in the format type:slug (the slug is the name attribute)
 # you can find a sym_secrets resource's type and slug by running `symflow resources list sym_secrets`
+# the type may be omitted if the slug is unique, and the resource may also be imported by its UUID
 terraform import sym_secrets.this aws_secrets_manager:prod
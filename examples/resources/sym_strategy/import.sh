@@ -1,3 +1,4 @@
 # sym_strategy can be imported in the format type:slug (the slug is the name attribute)
 # you can find a sym_strategy's type and slug by running `symflow resources list sym_strategy`
+# the type may be omitted if the slug is unique, and the resource may also be imported by its UUID
 terraform import sym_strategy.sso_strategy aws_sso:prod_strategy
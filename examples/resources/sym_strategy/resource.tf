@@ -32,9 +32,16 @@ resource "sym_strategy" "sso_main" {
   name = "flow-sso-main"
   label = "Flow SSO Main"
   integration_id = sym_integration.runtime_context.id
-  targets = [ sym_target.prod_break_glass.id ]
 
   settings = {
     instance_arn = "arn:aws:::instance/ssoinst-abcdefghi12314135325"
   }
+
+  # Tags are available to the strategy's implementation, e.g. to route high-risk targets to a different approver group
+  target {
+    id = sym_target.prod_break_glass.id
+    tags = {
+      risk = "high"
+    }
+  }
 }
@@ -1,15 +1,42 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/symopsio/terraform-provider-sym/sym/export"
 	"github.com/symopsio/terraform-provider-sym/sym/provider"
+	"github.com/symopsio/terraform-provider-sym/sym/tracing"
 )
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return provider.Provider()
-		},
-	})
+	// The provider binary is normally run by Terraform, but also supports an `export` subcommand
+	// that generates Terraform configuration for an existing org.
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := export.Run(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	ctx := context.Background()
+
+	// Resources are served by the SDK provider until they are ported to terraform-plugin-framework.
+	providerServer, err := provider.NewProviderServer(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := tf5server.Serve("registry.terraform.io/symopsio/sym", providerServer); err != nil {
+		log.Fatal(err)
+	}
+
+	// Flush any spans still buffered once Terraform is done with the provider.
+	if err := tracing.Shutdown(ctx); err != nil {
+		log.Printf("[WARN] unable to flush traces: %v", err)
+	}
 }
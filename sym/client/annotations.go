@@ -0,0 +1,94 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// ManagedByTerraform is the `managed_by` annotation set on every entity created or updated by this provider.
+const ManagedByTerraform = "terraform"
+
+// Annotations identify the Terraform configuration that manages the entities created or updated by this provider.
+type Annotations struct {
+	WorkspaceName string
+	RepoURL       string
+
+	// LockUIEdits asks the Sym API to reject changes to the entity made outside of Terraform, e.g. in the Sym web app.
+	LockUIEdits bool
+}
+
+// payload returns the fields added to every Create and Update request body.
+func (a Annotations) payload() map[string]interface{} {
+	annotations := map[string]string{"managed_by": ManagedByTerraform}
+	if a.WorkspaceName != "" {
+		annotations["terraform_workspace"] = a.WorkspaceName
+	}
+	if a.RepoURL != "" {
+		annotations["terraform_repo_url"] = a.RepoURL
+	}
+
+	return map[string]interface{}{
+		"annotations": annotations,
+		// Always sent, so that setting lock_ui_edits back to false unlocks the entity.
+		"lock_ui_edits": a.LockUIEdits,
+	}
+}
+
+// annotatedHttpClient is a SymHttpClient that adds Annotations to the body of every Create and Update request.
+type annotatedHttpClient struct {
+	SymHttpClient
+	annotations Annotations
+}
+
+func (c *annotatedHttpClient) WithContext(ctx context.Context) SymHttpClient {
+	return &annotatedHttpClient{
+		SymHttpClient: c.SymHttpClient.WithContext(ctx),
+		annotations:   c.annotations,
+	}
+}
+
+func (c *annotatedHttpClient) WithETag(etag string) SymHttpClient {
+	return &annotatedHttpClient{
+		SymHttpClient: c.SymHttpClient.WithETag(etag),
+		annotations:   c.annotations,
+	}
+}
+
+func (c *annotatedHttpClient) Create(path string, payload interface{}, result interface{}) (string, error) {
+	annotated, err := c.annotate(payload)
+	if err != nil {
+		return "", err
+	}
+	return c.SymHttpClient.Create(path, annotated, result)
+}
+
+func (c *annotatedHttpClient) Update(path string, payload interface{}, result interface{}) (string, error) {
+	annotated, err := c.annotate(payload)
+	if err != nil {
+		return "", err
+	}
+	return c.SymHttpClient.Update(path, annotated, result)
+}
+
+// annotate returns the JSON object for the payload with the annotations added.
+func (c *annotatedHttpClient) annotate(payload interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode numbers as json.Number so that they are re-encoded exactly.
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	var body map[string]interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+
+	for k, v := range c.annotations.payload() {
+		body[k] = v
+	}
+	return body, nil
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingHttpClient is a SymHttpClient that records the payload of the last request.
+type recordingHttpClient struct {
+	SymHttpClient
+	payload interface{}
+}
+
+func (c *recordingHttpClient) Create(_ string, payload interface{}, _ interface{}) (string, error) {
+	c.payload = payload
+	return "", nil
+}
+
+func (c *recordingHttpClient) Update(_ string, payload interface{}, _ interface{}) (string, error) {
+	c.payload = payload
+	return "", nil
+}
+
+func Test_annotatedHttpClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations Annotations
+		want        string
+	}{
+		{
+			"marker-only",
+			Annotations{},
+			`{"annotations":{"managed_by":"terraform"},"lock_ui_edits":false,"params":{"limit":12345678901234567890},"slug":"prod"}`,
+		},
+		{
+			"all-annotations",
+			Annotations{WorkspaceName: "prod", RepoURL: "https://github.com/example/sym", LockUIEdits: true},
+			`{"annotations":{"managed_by":"terraform","terraform_repo_url":"https://github.com/example/sym","terraform_workspace":"prod"},"lock_ui_edits":true,"params":{"limit":12345678901234567890},"slug":"prod"}`,
+		},
+	}
+
+	payload := struct {
+		Slug   string                 `json:"slug"`
+		Params map[string]interface{} `json:"params"`
+	}{"prod", map[string]interface{}{"limit": json.Number("12345678901234567890")}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &recordingHttpClient{}
+			c := &annotatedHttpClient{SymHttpClient: recorder, annotations: tt.annotations}
+
+			_, err := c.Create("/entities/flows", payload, nil)
+			assert.NoError(t, err)
+			created, _ := json.Marshal(recorder.payload)
+			assert.Equal(t, tt.want, string(created))
+
+			_, err = c.Update("/entities/flows/id", payload, nil)
+			assert.NoError(t, err)
+			updated, _ := json.Marshal(recorder.payload)
+			assert.Equal(t, tt.want, string(updated))
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"fmt"
+	"log"
+)
+
+// Bot is a Sym bot user, which authenticates with tokens issued by the TokenClient instead of logging in.
+type Bot struct {
+	Id    string `json:"id,omitempty"`
+	Name  string `json:"username"`
+	Label string `json:"label,omitempty"`
+
+	Metadata
+}
+
+type BotClient interface {
+	Create(bot Bot) (string, error)
+	Validate(bot Bot) error
+	Read(id string) (*Bot, error)
+	Find(name string) (*Bot, error)
+	List(query *Query) ([]Bot, error)
+	Update(bot Bot) (string, error)
+	Patch(id string, patch Patch) (string, error)
+	Delete(id string) (string, error)
+}
+
+func NewBotClient(httpClient SymHttpClient) BotClient {
+	return &botClient{
+		HttpClient: httpClient,
+	}
+}
+
+type botClient struct {
+	HttpClient SymHttpClient
+}
+
+func (c *botClient) Create(bot Bot) (string, error) {
+	log.Printf("Creating Bot: %v", bot)
+	result := Bot{}
+
+	if _, err := c.HttpClient.Create("/entities/bots", &bot, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Bot was not created")
+	}
+
+	log.Printf("Created Bot: %s", result.Id)
+	return result.Id, nil
+}
+
+func (c *botClient) Validate(bot Bot) error {
+	log.Printf("Validating Bot: %v", bot)
+	return c.HttpClient.Validate("/entities/bots/validate", &bot)
+}
+
+func (c *botClient) Read(id string) (*Bot, error) {
+	log.Printf("Getting Bot: %s", id)
+	result := Bot{}
+
+	if err := c.HttpClient.Read(fmt.Sprintf("/entities/bots/%s", id), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Got Bot: %s", result.Id)
+	return &result, nil
+}
+
+func (c *botClient) Find(name string) (*Bot, error) {
+	log.Printf("Getting Bot by name: %s", name)
+	var result []Bot
+
+	if err := c.HttpClient.ReadAll(NewQuery().Slug(name).Path("/entities/bots"), &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) != 1 {
+		return nil, fmt.Errorf("one Bot with the name %s was expected, but %v were found", name, len(result))
+	}
+
+	log.Printf("Got Bot by name: %s (%s)", name, result[0].Id)
+	return &result[0], nil
+}
+
+func (c *botClient) List(query *Query) ([]Bot, error) {
+	log.Printf("Listing Sym Bots")
+	var result []Bot
+
+	if err := c.HttpClient.ReadAll(query.Path("/entities/bots"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Sym Bots", len(result))
+	return result, nil
+}
+
+func (c *botClient) Update(bot Bot) (string, error) {
+	log.Printf("Updating Bot: %v", bot)
+	result := Bot{}
+
+	if _, err := c.HttpClient.Update(fmt.Sprintf("/entities/bots/%s", bot.Id), &bot, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Bot was not updated")
+	}
+
+	log.Printf("Updated Bot: %s", result.Id)
+	return result.Id, nil
+}
+
+func (c *botClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching Bot %s: %v", id, patch)
+	result := Bot{}
+
+	if _, err := c.HttpClient.Update(fmt.Sprintf("/entities/bots/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Bot was not updated")
+	}
+
+	log.Printf("Updated Bot: %s", result.Id)
+	return result.Id, nil
+}
+
+func (c *botClient) Delete(id string) (string, error) {
+	log.Printf("Deleting Bot: %s", id)
+
+	if err := c.HttpClient.Delete(fmt.Sprintf("/entities/bots/%s", id)); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// cachingHttpClient is a SymHttpClient that caches the entities it reads for the lifetime of the provider,
+// i.e. a single Terraform run.
+//
+// The first Read of an entity lists every entity of its type, so that reading the rest of them requires
+// no further requests. Once entities of a type are changed, they are read directly instead, so that
+// reading each entity after changing it, e.g. during an apply, does not list them all again. Identical
+// concurrent reads, e.g. the same data source lookup in several modules, are only requested once. Any
+// Create, Update or Delete invalidates the cached entities of that type.
+//
+// Entities read from a list have no ETag, so updates to them are not checked for concurrent changes.
+type cachingHttpClient struct {
+	SymHttpClient
+	cache *readCache
+}
+
+// readCache is shared by every copy of a cachingHttpClient, e.g. those returned by WithContext.
+type readCache struct {
+	group singleflight.Group
+
+	mu sync.Mutex
+	// lists are the cached responses of ReadAll, by path.
+	lists map[string]*cachedList
+	// inflight counts the requests in progress for each path, which are shared through group.
+	inflight map[string]int
+	// changed are the collections which have been invalidated, whose entities are no longer listed by Read.
+	changed map[string]bool
+	// generation is incremented by every invalidation, so that responses to requests made before
+	// an invalidation are not cached.
+	generation int
+}
+
+// cachedList is a cached list of entities, along with an index of the entities by ID.
+type cachedList struct {
+	items []json.RawMessage
+	byId  map[string]json.RawMessage
+}
+
+func newCachingHttpClient(httpClient SymHttpClient) *cachingHttpClient {
+	return &cachingHttpClient{
+		SymHttpClient: httpClient,
+		cache: &readCache{
+			lists:    make(map[string]*cachedList),
+			inflight: make(map[string]int),
+			changed:  make(map[string]bool),
+		},
+	}
+}
+
+func (c *cachingHttpClient) WithContext(ctx context.Context) SymHttpClient {
+	return &cachingHttpClient{
+		SymHttpClient: c.SymHttpClient.WithContext(ctx),
+		cache:         c.cache,
+	}
+}
+
+func (c *cachingHttpClient) WithETag(etag string) SymHttpClient {
+	return &cachingHttpClient{
+		SymHttpClient: c.SymHttpClient.WithETag(etag),
+		cache:         c.cache,
+	}
+}
+
+// Read returns the entity from the cached list of every entity of its type, listing them if needed.
+// Paths which do not identify a single entity, entities missing from the list, and entity types which
+// cannot be listed or whose list was invalidated are read directly.
+func (c *cachingHttpClient) Read(path string, result interface{}) error {
+	collection, id := splitEntityPath(path)
+	if id == "" {
+		return c.SymHttpClient.Read(path, result)
+	}
+
+	c.cache.mu.Lock()
+	changed := c.cache.changed[collection]
+	c.cache.mu.Unlock()
+	if changed {
+		return c.SymHttpClient.Read(path, result)
+	}
+
+	list, err := c.list(collection)
+	if err != nil {
+		return c.SymHttpClient.Read(path, result)
+	}
+
+	if entity, ok := list.byId[id]; ok {
+		return json.Unmarshal(entity, result)
+	}
+	return c.SymHttpClient.Read(path, result)
+}
+
+func (c *cachingHttpClient) ReadAll(path string, result interface{}) error {
+	list, err := c.list(path)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(list.items)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, result)
+}
+
+func (c *cachingHttpClient) Do(method, path string, payload interface{}) (string, error) {
+	if method != "GET" {
+		c.invalidate(path)
+	}
+	return c.SymHttpClient.Do(method, path, payload)
+}
+
+func (c *cachingHttpClient) Create(path string, payload interface{}, result interface{}) (string, error) {
+	defer c.invalidate(path)
+	return c.SymHttpClient.Create(path, payload, result)
+}
+
+func (c *cachingHttpClient) Update(path string, payload interface{}, result interface{}) (string, error) {
+	defer c.invalidate(path)
+	return c.SymHttpClient.Update(path, payload, result)
+}
+
+func (c *cachingHttpClient) Delete(path string) error {
+	defer c.invalidate(path)
+	return c.SymHttpClient.Delete(path)
+}
+
+// list returns the cached list of entities at the path, listing them if they are not cached.
+// Concurrent calls for the same path share a single request.
+func (c *cachingHttpClient) list(path string) (*cachedList, error) {
+	c.cache.mu.Lock()
+	list, ok := c.cache.lists[path]
+	generation := c.cache.generation
+	c.cache.mu.Unlock()
+	if ok {
+		return list, nil
+	}
+
+	v, err, _ := c.cache.group.Do(path, func() (interface{}, error) {
+		c.cache.mu.Lock()
+		c.cache.inflight[path]++
+		c.cache.mu.Unlock()
+		defer func() {
+			c.cache.mu.Lock()
+			if c.cache.inflight[path]--; c.cache.inflight[path] == 0 {
+				delete(c.cache.inflight, path)
+			}
+			c.cache.mu.Unlock()
+		}()
+
+		var items []json.RawMessage
+		if err := c.SymHttpClient.ReadAll(path, &items); err != nil {
+			return nil, err
+		}
+
+		list := &cachedList{items: items, byId: make(map[string]json.RawMessage, len(items))}
+		for _, item := range items {
+			var entity struct {
+				Id string `json:"id"`
+			}
+			if err := json.Unmarshal(item, &entity); err == nil && entity.Id != "" {
+				list.byId[entity.Id] = item
+			}
+		}
+
+		c.cache.mu.Lock()
+		if c.cache.generation == generation {
+			c.cache.lists[path] = list
+		}
+		c.cache.mu.Unlock()
+		return list, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*cachedList), nil
+}
+
+// invalidate removes every cached list of the type of entity at the path.
+func (c *cachingHttpClient) invalidate(path string) {
+	collection, _ := splitEntityPath(path)
+
+	c.cache.mu.Lock()
+	defer c.cache.mu.Unlock()
+
+	c.cache.generation++
+	c.cache.changed[collection] = true
+	for cached := range c.cache.lists {
+		if inCollection(cached, collection) {
+			delete(c.cache.lists, cached)
+		}
+	}
+	// Forget any in-flight request, so that later reads are not given a response from before this change.
+	for path := range c.cache.inflight {
+		if inCollection(path, collection) {
+			c.cache.group.Forget(path)
+		}
+	}
+}
+
+// inCollection reports whether the list path, which may have a query, lists entities of the collection.
+func inCollection(path, collection string) bool {
+	return path == collection || strings.HasPrefix(path, collection+"?")
+}
+
+// splitEntityPath splits a path such as `/entities/flows/<id>` into the path of the entity type's collection
+// and the entity's ID. The ID is empty if the path does not identify a single entity.
+func splitEntityPath(path string) (collection, id string) {
+	path = strings.SplitN(path, "?", 2)[0]
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) == 3 && segments[0] == "entities" {
+		return "/entities/" + segments[1], segments[2]
+	}
+	return path, ""
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingServer serves two Targets, and counts the requests made for each path and query.
+func countingServer(t *testing.T, release <-chan struct{}) (*httptest.Server, func(string) int) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.Method+" "+r.URL.RequestURI()]++
+		mu.Unlock()
+
+		if release != nil {
+			<-release
+		}
+
+		switch r.Method + " " + r.URL.RequestURI() {
+		case "GET /entities/access-targets?slug=prod":
+			fmt.Fprint(w, `[{"id": "target-1", "slug": "prod"}]`)
+		case "GET /entities/access-targets":
+			fmt.Fprint(w, `[{"id": "target-1", "slug": "prod"}, {"id": "target-2", "slug": "staging"}]`)
+		case "GET /entities/access-targets/target-3":
+			fmt.Fprint(w, `{"id": "target-3", "slug": "dev"}`)
+		case "PATCH /entities/access-targets/target-1":
+			fmt.Fprint(w, `{"id": "target-1", "slug": "production"}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return server, func(request string) int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests[request]
+	}
+}
+
+func Test_cachingHttpClient_Read(t *testing.T) {
+	server, requests := countingServer(t, nil)
+	defer server.Close()
+	c := newApiClient(newCachingHttpClient(NewSymHttpClient(server.URL, "token")))
+
+	for _, id := range []string{"target-1", "target-2", "target-1"} {
+		target, err := c.Target.Read(id)
+		assert.NoError(t, err)
+		assert.Equal(t, id, target.Id)
+	}
+	assert.Equal(t, 1, requests("GET /entities/access-targets"))
+
+	// Entities missing from the list are read directly.
+	target, err := c.Target.Read("target-3")
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", target.Name)
+	assert.Equal(t, 1, requests("GET /entities/access-targets/target-3"))
+
+	// Updates invalidate the list, after which entities of that type are read directly.
+	_, err = c.Target.Patch("target-1", Patch{"slug": "production"})
+	assert.NoError(t, err)
+	_, err = c.Target.Read("target-3")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests("GET /entities/access-targets/target-3"))
+	assert.Equal(t, 1, requests("GET /entities/access-targets"))
+
+	// Lists are fetched again after being invalidated.
+	_, err = c.Target.List(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests("GET /entities/access-targets"))
+}
+
+func Test_cachingHttpClient_concurrentFind(t *testing.T) {
+	release := make(chan struct{})
+	server, requests := countingServer(t, release)
+	defer server.Close()
+	c := newApiClient(newCachingHttpClient(NewSymHttpClient(server.URL, "token")))
+
+	var wg sync.WaitGroup
+	var found int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Target.Find("prod", ""); err == nil {
+				atomic.AddInt32(&found, 1)
+			}
+		}()
+	}
+
+	// Wait for the concurrent calls to be made before responding to the first of them.
+	assert.Eventually(t, func() bool { return requests("GET /entities/access-targets?slug=prod") == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, 1, requests("GET /entities/access-targets?slug=prod"))
+	assert.Equal(t, int32(10), found)
+}
+
+func Test_cachingHttpClient_invalidateInFlight(t *testing.T) {
+	release := make(chan struct{})
+	server, requests := countingServer(t, release)
+	defer server.Close()
+	c := newCachingHttpClient(NewSymHttpClient(server.URL, "token"))
+
+	var wg sync.WaitGroup
+	find := func() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.list("/entities/access-targets?slug=prod")
+			assert.NoError(t, err)
+		}()
+	}
+
+	find()
+	assert.Eventually(t, func() bool { return requests("GET /entities/access-targets?slug=prod") == 1 }, time.Second, time.Millisecond)
+
+	// A change made while the list is in flight means that later reads must not share its response.
+	c.invalidate("/entities/access-targets/target-1")
+	find()
+	assert.Eventually(t, func() bool { return requests("GET /entities/access-targets?slug=prod") == 2 }, time.Second, time.Millisecond)
+
+	close(release)
+	wg.Wait()
+}
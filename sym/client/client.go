@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"os"
 )
 
@@ -17,12 +18,46 @@ type ApiClient struct {
 	ErrorLogger    ErrorLoggerClient
 	LogDestination LogDestinationClient
 	FlowsFilter    FlowsFilterClient
+	Bot            BotClient
+	Token          TokenClient
+
+	httpClient SymHttpClient
 }
 
 // New creates a new symflow client
 func New(authToken string) *ApiClient {
-	httpClient := NewSymHttpClient(getApiUrl(), authToken)
+	return newApiClient(NewSymHttpClient(getApiUrl(), authToken))
+}
+
+// WithContext returns a copy of the ApiClient whose requests are made with the given context,
+// so that they are traced as children of any span it carries.
+func (c *ApiClient) WithContext(ctx context.Context) *ApiClient {
+	return newApiClient(c.httpClient.WithContext(ctx))
+}
+
+// WithETag returns a copy of the ApiClient whose Update and Delete requests fail with
+// utils.ErrAPIPreconditionFailed if the entity no longer has the given ETag, i.e. if it was changed
+// since it was read.
+func (c *ApiClient) WithETag(etag string) *ApiClient {
+	return newApiClient(c.httpClient.WithETag(etag))
+}
 
+// WithReadCache returns a copy of the ApiClient which caches the entities it reads, listing every entity
+// of a type on the first read of one of them. See cachingHttpClient.
+func (c *ApiClient) WithReadCache() *ApiClient {
+	return newApiClient(newCachingHttpClient(c.httpClient))
+}
+
+// WithAnnotations returns a copy of the ApiClient which adds the given Annotations to every entity it
+// creates or updates.
+func (c *ApiClient) WithAnnotations(annotations Annotations) *ApiClient {
+	return newApiClient(&annotatedHttpClient{
+		SymHttpClient: c.httpClient,
+		annotations:   annotations,
+	})
+}
+
+func newApiClient(httpClient SymHttpClient) *ApiClient {
 	return &ApiClient{
 		Integration:    NewIntegrationClient(httpClient),
 		Secret:         NewSecretClient(httpClient),
@@ -35,6 +70,10 @@ func New(authToken string) *ApiClient {
 		ErrorLogger:    NewErrorLoggerClient(httpClient),
 		LogDestination: NewLogDestinationClient(httpClient),
 		FlowsFilter:    NewFlowsFilterClient(httpClient),
+		Bot:            NewBotClient(httpClient),
+		Token:          NewTokenClient(httpClient),
+
+		httpClient: httpClient,
 	}
 }
 
@@ -45,3 +84,7 @@ func getApiUrl() string {
 	}
 	return apiUrl
 }
+
+// Patch is a partial update to an entity, mapping the API fields to change to their new values.
+// Fields which are not included are left unchanged by the Sym API.
+type Patch map[string]interface{}
@@ -17,6 +17,8 @@ type Environment struct {
 	Integrations      map[string]string `json:"integrations"`
 	ErrorLoggerId     string            `json:"error_logger_id,omitempty"`
 	LogDestinationIds []string          `json:"log_destination_ids,omitempty"`
+
+	Metadata
 }
 
 // String representation
@@ -33,9 +35,12 @@ func (s Environment) String() string {
 // Interface defining methods that the client exposes
 type EnvironmentClient interface {
 	Create(environment Environment) (string, error)
+	Validate(environment Environment) error
 	Read(id string) (*Environment, error)
 	Find(name string) (*Environment, error)
+	List(query *Query) ([]Environment, error)
 	Update(environment Environment) (string, error)
+	Patch(id string, patch Patch) (string, error)
 	Delete(id string) (string, error)
 }
 
@@ -72,6 +77,11 @@ func (c *environmentClient) Create(environment Environment) (string, error) {
 	return result.Id, nil
 }
 
+func (c *environmentClient) Validate(environment Environment) error {
+	log.Printf("Validating Sym Environment: %v", environment)
+	return c.HttpClient.Validate("/entities/environments/validate", &environment)
+}
+
 // Read the data for an existing Environment
 func (c *environmentClient) Read(id string) (*Environment, error) {
 	log.Printf("Getting Sym Environment: %s", id)
@@ -89,7 +99,7 @@ func (c *environmentClient) Find(name string) (*Environment, error) {
 	log.Printf("Getting Sym Environment by name: %s", name)
 	var result []Environment
 
-	if err := c.HttpClient.Read(fmt.Sprintf("/entities/environments?slug=%s", name), &result); err != nil {
+	if err := c.HttpClient.ReadAll(NewQuery().Slug(name).Path("/entities/environments"), &result); err != nil {
 		return nil, err
 	}
 
@@ -102,6 +112,18 @@ func (c *environmentClient) Find(name string) (*Environment, error) {
 	return &result[0], nil
 }
 
+func (c *environmentClient) List(query *Query) ([]Environment, error) {
+	log.Printf("Listing Sym Environments")
+	var result []Environment
+
+	if err := c.HttpClient.ReadAll(query.Path("/entities/environments"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Sym Environments", len(result))
+	return result, nil
+}
+
 // Update an existing Environment
 func (c *environmentClient) Update(environment Environment) (string, error) {
 	log.Printf("Updating Sym Environment: %v", environment)
@@ -119,6 +141,22 @@ func (c *environmentClient) Update(environment Environment) (string, error) {
 	return result.Id, nil
 }
 
+func (c *environmentClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching Sym Environment %s: %v", id, patch)
+	result := Environment{}
+
+	if _, err := c.HttpClient.Update(fmt.Sprintf("/entities/environments/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", utils.GenerateError("An error happened during the Environment update. Please contact Sym support.", utils.DocsSupport)
+	}
+
+	log.Printf("Updated Sym Environment: %s", result.Id)
+	return result.Id, nil
+}
+
 // Delete an existing Environment
 func (c *environmentClient) Delete(id string) (string, error) {
 	log.Printf("Deleting Sym Environment: %s", id)
@@ -7,15 +7,21 @@ import (
 
 type ErrorLogger struct {
 	Id            string `json:"id,omitempty"`
+	Slug          string `json:"slug,omitempty"`
 	IntegrationId string `json:"integration_id"`
 	Destination   string `json:"destination"`
+
+	Metadata
 }
 
 type ErrorLoggerClient interface {
 	Create(errorLogger ErrorLogger) (string, error)
+	Validate(errorLogger ErrorLogger) error
 	Read(id string) (*ErrorLogger, error)
 	Find(slug string) (*ErrorLogger, error)
+	List(query *Query) ([]ErrorLogger, error)
 	Update(errorLogger ErrorLogger) (string, error)
+	Patch(id string, patch Patch) (string, error)
 	Delete(id string) (string, error)
 }
 
@@ -45,6 +51,11 @@ func (c *errorLoggerClient) Create(errorLogger ErrorLogger) (string, error) {
 	return result.Id, nil
 }
 
+func (c *errorLoggerClient) Validate(errorLogger ErrorLogger) error {
+	log.Printf("Validating ErrorLogger: %v", errorLogger)
+	return c.HttpClient.Validate("/entities/error-loggers/validate", &errorLogger)
+}
+
 func (c *errorLoggerClient) Read(id string) (*ErrorLogger, error) {
 	log.Printf("Getting ErrorLogger: %s", id)
 	result := ErrorLogger{}
@@ -61,7 +72,7 @@ func (c *errorLoggerClient) Find(slug string) (*ErrorLogger, error) {
 	log.Printf("Getting ErrorLogger by slug: %s", slug)
 	var result []ErrorLogger
 
-	if err := c.HttpClient.Read(fmt.Sprintf("/entities/error-loggers?slug=%s", slug), &result); err != nil {
+	if err := c.HttpClient.ReadAll(NewQuery().Slug(slug).Path("/entities/error-loggers"), &result); err != nil {
 		return nil, err
 	}
 
@@ -73,6 +84,18 @@ func (c *errorLoggerClient) Find(slug string) (*ErrorLogger, error) {
 	return &result[0], nil
 }
 
+func (c *errorLoggerClient) List(query *Query) ([]ErrorLogger, error) {
+	log.Printf("Listing Sym ErrorLoggers")
+	var result []ErrorLogger
+
+	if err := c.HttpClient.ReadAll(query.Path("/entities/error-loggers"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Sym ErrorLoggers", len(result))
+	return result, nil
+}
+
 func (c *errorLoggerClient) Update(errorLogger ErrorLogger) (string, error) {
 	log.Printf("Updating ErrorLogger: %v", errorLogger)
 	result := ErrorLogger{}
@@ -89,6 +112,22 @@ func (c *errorLoggerClient) Update(errorLogger ErrorLogger) (string, error) {
 	return result.Id, nil
 }
 
+func (c *errorLoggerClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching ErrorLogger %s: %v", id, patch)
+	result := ErrorLogger{}
+
+	if _, err := c.HttpClient.Update(fmt.Sprintf("/entities/error-loggers/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates ErrorLogger was not updated")
+	}
+
+	log.Printf("Updated ErrorLogger: %s", result.Id)
+	return result.Id, nil
+}
+
 func (c *errorLoggerClient) Delete(id string) (string, error) {
 	log.Printf("Deleting ErrorLogger: %s", id)
 
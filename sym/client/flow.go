@@ -15,6 +15,8 @@ type Flow struct {
 	EnvironmentId  string                 `json:"environment_id"`
 	Vars           Settings               `json:"vars"`
 	Params         map[string]interface{} `json:"params"`
+
+	Metadata
 }
 
 // Helper Functions for Types ///////////////////
@@ -34,9 +36,12 @@ func (s Flow) String() string {
 
 type FlowClient interface {
 	Create(flow Flow) (string, error)
+	Validate(flow Flow) error
 	Read(id string) (*Flow, error)
 	Find(name string) (*Flow, error)
+	List(query *Query) ([]Flow, error)
 	Update(flow Flow) (string, error)
+	Patch(id string, patch Patch) (string, error)
 	Delete(id string) (string, error)
 }
 
@@ -68,6 +73,11 @@ func (c *flowClient) Create(flow Flow) (string, error) {
 	return result.Id, nil
 }
 
+func (c *flowClient) Validate(flow Flow) error {
+	log.Printf("Validating Sym Flow: %v", flow)
+	return c.HttpClient.Validate("/entities/flows/validate", &flow)
+}
+
 func (c *flowClient) Read(id string) (*Flow, error) {
 	log.Printf("Getting Sym Flow: %s", id)
 	result := Flow{}
@@ -84,7 +94,7 @@ func (c *flowClient) Find(name string) (*Flow, error) {
 	log.Printf("Getting Flow by name: %s", name)
 	var result []Flow
 
-	if err := c.HttpClient.Read(fmt.Sprintf("/entities/flows?slug=%s", name), &result); err != nil {
+	if err := c.HttpClient.ReadAll(NewQuery().Slug(name).Path("/entities/flows"), &result); err != nil {
 		return nil, err
 	}
 
@@ -96,6 +106,18 @@ func (c *flowClient) Find(name string) (*Flow, error) {
 	return &result[0], nil
 }
 
+func (c *flowClient) List(query *Query) ([]Flow, error) {
+	log.Printf("Listing Sym Flows")
+	var result []Flow
+
+	if err := c.HttpClient.ReadAll(query.Path("/entities/flows"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Sym Flows", len(result))
+	return result, nil
+}
+
 func (c *flowClient) Update(flow Flow) (string, error) {
 	log.Printf("Updating Sym Flow: %v", flow)
 	result := Flow{}
@@ -112,6 +134,22 @@ func (c *flowClient) Update(flow Flow) (string, error) {
 	return result.Id, nil
 }
 
+func (c *flowClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching Sym Flow %s: %v", id, patch)
+	result := Flow{}
+
+	if _, err := c.HttpClient.Update(fmt.Sprintf("/entities/flows/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Sym Flow was not updated")
+	}
+
+	log.Printf("Updated Sym Flow: %s", result.Id)
+	return result.Id, nil
+}
+
 func (c *flowClient) Delete(id string) (string, error) {
 	log.Printf("Deleting Sym Flow: %s", id)
 
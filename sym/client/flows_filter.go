@@ -14,16 +14,20 @@ type FlowsFilter struct {
 	Implementation string            `json:"implementation"`
 	Vars           Settings          `json:"vars"`
 	Integrations   map[string]string `json:"integrations"`
+	Owner          string            `json:"owner,omitempty"`
+
+	Metadata
 }
 
 // String representation
 func (s FlowsFilter) String() string {
 	return fmt.Sprintf(
-		"{id=%s, implementation=%s, vars=%s, integrations=%s}",
+		"{id=%s, implementation=%s, vars=%s, integrations=%s, owner=%s}",
 		s.Id,
 		s.Implementation,
 		s.Vars,
 		s.Integrations,
+		s.Owner,
 	)
 }
 
@@ -32,8 +36,10 @@ func (s FlowsFilter) String() string {
 // Interface defining methods that the client exposes
 type FlowsFilterClient interface {
 	Create(flowsFilter FlowsFilter) (string, error)
+	Validate(flowsFilter FlowsFilter) error
 	Read() (*FlowsFilter, error)
 	Update(flowsFilter FlowsFilter) (string, error)
+	Patch(patch Patch) (string, error)
 	Delete() (string, error)
 }
 
@@ -70,6 +76,11 @@ func (c *flowsFilterClient) Create(flowsFilter FlowsFilter) (string, error) {
 	return result.Id, nil
 }
 
+func (c *flowsFilterClient) Validate(flowsFilter FlowsFilter) error {
+	log.Printf("Validating Sym FlowsFilter: %v", flowsFilter)
+	return c.HttpClient.Validate("/entities/flows-filter/validate", &flowsFilter)
+}
+
 // Read the data for an existing FlowsFilter
 func (c *flowsFilterClient) Read() (*FlowsFilter, error) {
 	log.Printf("Getting Sym FlowsFilter")
@@ -100,6 +111,22 @@ func (c *flowsFilterClient) Update(flowsFilter FlowsFilter) (string, error) {
 	return result.Id, nil
 }
 
+func (c *flowsFilterClient) Patch(patch Patch) (string, error) {
+	log.Printf("Patching Sym FlowsFilter: %v", patch)
+	result := FlowsFilter{}
+
+	if _, err := c.HttpClient.Update("/entities/flows-filter", patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", utils.GenerateError("An error happened during the FlowsFilter update. Please contact Sym support.", utils.DocsSupport)
+	}
+
+	log.Printf("Updated Sym FlowsFilter: %s", result.Id)
+	return result.Id, nil
+}
+
 // Delete an existing FlowsFilter
 func (c *flowsFilterClient) Delete() (string, error) {
 	log.Printf("Deleting Sym FlowsFilter")
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"log"
@@ -9,7 +10,13 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/symopsio/terraform-provider-sym/sym/tracing"
 	"github.com/symopsio/terraform-provider-sym/sym/utils"
 )
 
@@ -17,6 +24,7 @@ func NewSymHttpClient(apiUrl, token string) SymHttpClient {
 	return &symHttpClient{
 		apiUrl: apiUrl,
 		jwt:    token,
+		ctx:    context.Background(),
 	}
 }
 
@@ -24,13 +32,49 @@ type SymHttpClient interface {
 	Do(method, path string, payload interface{}) (string, error)
 	Create(path string, payload interface{}, result interface{}) (string, error)
 	Read(path string, result interface{}) error
+	ReadAll(path string, result interface{}) error
 	Update(path string, payload interface{}, result interface{}) (string, error)
 	Delete(path string) error
+
+	// Validate asks the Sym API whether it would accept the payload, without creating or changing anything.
+	Validate(path string, payload interface{}) error
+
+	// WithContext returns a copy of the client whose requests are made with the given context.
+	WithContext(ctx context.Context) SymHttpClient
+
+	// WithETag returns a copy of the client whose Update and Delete requests are only applied if the
+	// entity still has the given ETag. An empty ETag applies them unconditionally.
+	WithETag(etag string) SymHttpClient
+}
+
+// sensitivePayload is implemented by request payloads which must never be written to the logs.
+type sensitivePayload interface {
+	sensitive()
 }
 
 type symHttpClient struct {
-	apiUrl string
-	jwt    string
+	apiUrl  string
+	jwt     string
+	ctx     context.Context
+	ifMatch string
+}
+
+func (c *symHttpClient) WithContext(ctx context.Context) SymHttpClient {
+	return &symHttpClient{
+		apiUrl:  c.apiUrl,
+		jwt:     c.jwt,
+		ctx:     ctx,
+		ifMatch: c.ifMatch,
+	}
+}
+
+func (c *symHttpClient) WithETag(etag string) SymHttpClient {
+	return &symHttpClient{
+		apiUrl:  c.apiUrl,
+		jwt:     c.jwt,
+		ctx:     c.ctx,
+		ifMatch: etag,
+	}
 }
 
 func (c *symHttpClient) getUrl(path string) string {
@@ -39,27 +83,65 @@ func (c *symHttpClient) getUrl(path string) string {
 }
 
 func (c *symHttpClient) Do(method string, path string, payload interface{}) (string, error) {
-	url := c.getUrl(path)
+	body, _, err := c.send(method, c.getUrl(path), path, payload)
+	return body, err
+}
+
+// send makes a request to the given URL inside a span, and returns the response body and headers.
+// The path is only used to name the span and in error messages.
+func (c *symHttpClient) send(method, url, path string, payload interface{}) (body string, header http.Header, err error) {
+	requestID := uuid.New().String()
+
+	ctx, span := tracing.Tracer().Start(c.ctx, method+" "+path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(method),
+			semconv.URLFull(url),
+			tracing.RequestIDKey.String(requestID),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	return c.do(ctx, span, method, url, path, requestID, payload)
+}
+
+func (c *symHttpClient) do(ctx context.Context, span trace.Span, method, url, path, requestID string, payload interface{}) (string, http.Header, error) {
 	b, err := json.Marshal(payload)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	log.Printf("submitting request: %s %s %s", method, path, string(b))
-	req, err := http.NewRequest(method, url, bytes.NewReader(b))
+	logged := string(b)
+	if _, ok := payload.(sensitivePayload); ok {
+		logged = "(sensitive)"
+	}
+	log.Printf("submitting request: %s %s %s", method, path, logged)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(b))
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	requestID := uuid.New().String()
 	req.Header.Set("Authorization", "Bearer "+c.jwt)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("X-Sym-Request-ID", requestID)
+	if c.ifMatch != "" && (method == "PATCH" || method == "DELETE") {
+		req.Header.Set("If-Match", c.ifMatch)
+	}
+	// Propagate the trace context to the Sym API via the W3C traceparent and tracestate headers.
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		// no status code if there was an error at this point
-		return "", utils.ErrAPIConnect(path, requestID)
+		return "", nil, utils.ErrAPIConnect(path, requestID)
 	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
 
 	body, err := io.ReadAll(resp.Body)
 
@@ -68,28 +150,30 @@ func (c *symHttpClient) Do(method string, path string, payload interface{}) (str
 		errorBody := utils.ErrorResponse{}
 		err = json.Unmarshal(body, &errorBody)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
-		return "", utils.ErrAPIBadRequest(errorBody.Errors)
+		return "", nil, utils.ErrAPIBadRequest(errorBody.Errors)
 	} else if resp.StatusCode == 401 {
-		return "", utils.ErrConfigFileNoJWT
+		return "", nil, utils.ErrConfigFileNoJWT
 	} else if resp.StatusCode == 403 {
-		return "", utils.ErrUserIsNotAdmin
+		return "", nil, utils.ErrUserIsNotAdmin
 	} else if resp.StatusCode == 404 {
-		return "", utils.ErrAPINotFound(path, requestID)
+		return "", nil, utils.ErrAPINotFound(path, requestID)
+	} else if resp.StatusCode == 412 {
+		return "", nil, utils.ErrAPIPreconditionFailed(path, requestID)
 	}
 
 	if err != nil {
 		// If we don't have a specific error for the status code, and we got an error message in the response,
 		// display the full error message to the user.
-		return "", err
+		return "", nil, err
 	} else if resp.StatusCode > 400 {
 		// We weren't able to get an error message from the API, and we don't have a specific error message
 		// for this status code, but we know it failed. Display a generic error message to the user.
-		return "", utils.ErrAPIUnexpected(path, requestID, resp.StatusCode)
+		return "", nil, utils.ErrAPIUnexpected(path, requestID, resp.StatusCode)
 	}
 
-	return string(body), nil
+	return string(body), resp.Header, nil
 }
 
 func (c *symHttpClient) Create(path string, payload interface{}, result interface{}) (string, error) {
@@ -107,11 +191,19 @@ func (c *symHttpClient) Create(path string, payload interface{}, result interfac
 }
 
 func (c *symHttpClient) Read(path string, result interface{}) error {
-	body, err := c.Do("GET", path, nil)
+	body, header, err := c.send("GET", c.getUrl(path), path, nil)
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal([]byte(body), result)
+
+	if err := json.Unmarshal([]byte(body), result); err != nil {
+		return err
+	}
+
+	if entity, ok := result.(etagged); ok {
+		entity.setETag(header.Get("ETag"))
+	}
+	return nil
 }
 
 func (c *symHttpClient) Update(path string, payload interface{}, result interface{}) (string, error) {
@@ -135,3 +227,11 @@ func (c *symHttpClient) Delete(path string) error {
 
 	return nil
 }
+
+func (c *symHttpClient) Validate(path string, payload interface{}) error {
+	if _, err := c.Do("POST", path, payload); err != nil {
+		return err
+	}
+
+	return nil
+}
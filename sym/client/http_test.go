@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_symHttpClient_ETag(t *testing.T) {
+	const currentETag = `"v2"`
+
+	tests := []struct {
+		name        string
+		etag        string
+		wantIfMatch string
+		wantErr     string
+	}{
+		{"no-etag", "", "", ""},
+		{"current-etag", currentETag, currentETag, ""},
+		{"stale-etag", `"v1"`, `"v1"`, "The Sym entity was changed since it was last read by Terraform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotIfMatch []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "GET" {
+					assert.Empty(t, r.Header.Get("If-Match"))
+					w.Header().Set("ETag", currentETag)
+					fmt.Fprint(w, `{"id": "flow-id", "slug": "prod"}`)
+					return
+				}
+
+				ifMatch := r.Header.Get("If-Match")
+				gotIfMatch = append(gotIfMatch, ifMatch)
+				if ifMatch != "" && ifMatch != currentETag {
+					w.WriteHeader(http.StatusPreconditionFailed)
+					return
+				}
+				fmt.Fprint(w, `{"id": "flow-id", "slug": "prod"}`)
+			}))
+			defer server.Close()
+
+			c := newApiClient(NewSymHttpClient(server.URL, "token")).WithAnnotations(Annotations{})
+
+			flow, err := c.Flow.Read("flow-id")
+			assert.NoError(t, err)
+			assert.Equal(t, currentETag, flow.ETag)
+
+			_, updateErr := c.WithETag(tt.etag).Flow.Update(Flow{Id: "flow-id", Name: "prod"})
+			_, deleteErr := c.WithETag(tt.etag).Flow.Delete("flow-id")
+			for _, err := range []error{updateErr, deleteErr} {
+				if tt.wantErr != "" {
+					assert.ErrorContains(t, err, tt.wantErr)
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+			assert.Equal(t, []string{tt.wantIfMatch, tt.wantIfMatch}, gotIfMatch)
+		})
+	}
+}
+
+func Test_symHttpClient_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"valid", http.StatusOK, `{}`, ""},
+		{
+			"invalid",
+			http.StatusBadRequest,
+			`{"error": true, "errors": [{"field": "type", "message": "unknown Target type"}]}`,
+			"The Sym API returned a bad request error: [{type unknown Target type}]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "POST", r.Method)
+				assert.Equal(t, "/entities/access-targets/validate", r.URL.Path)
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			c := newApiClient(NewSymHttpClient(server.URL, "token")).WithReadCache()
+
+			err := c.Target.Validate(Target{Type: "okta_group", Name: "prod"})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_symHttpClient_sensitivePayload(t *testing.T) {
+	var gotBody map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/entities/secrets/secret-id/value", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	c := newApiClient(NewSymHttpClient(server.URL, "token"))
+	assert.NoError(t, c.Secret.SetValue("secret-id", "hunter2"))
+
+	// The value is sent to the Sym API, but never written to the logs.
+	assert.Equal(t, map[string]string{"value": "hunter2"}, gotBody)
+	assert.NotContains(t, logs.String(), "hunter2")
+	assert.Contains(t, logs.String(), "(sensitive)")
+}
@@ -12,6 +12,8 @@ type Integration struct {
 	Name       string   `json:"slug"`
 	ExternalId string   `json:"external_id"`
 	Label      string   `json:"label,omitempty"`
+
+	Metadata
 }
 
 func (s Integration) String() string {
@@ -20,9 +22,12 @@ func (s Integration) String() string {
 
 type IntegrationClient interface {
 	Create(integration Integration) (string, error)
+	Validate(integration Integration) error
 	Read(id string) (*Integration, error)
 	Find(name string, integrationType string) (*Integration, error)
+	List(query *Query) ([]Integration, error)
 	Update(integration Integration) (string, error)
+	Patch(id string, patch Patch) (string, error)
 	Delete(id string) (string, error)
 }
 
@@ -52,6 +57,11 @@ func (i *integrationClient) Create(integration Integration) (string, error) {
 	return result.Id, nil
 }
 
+func (i *integrationClient) Validate(integration Integration) error {
+	log.Printf("Validating Sym Integration: %v", integration)
+	return i.HttpClient.Validate("/entities/integrations/validate", &integration)
+}
+
 func (i *integrationClient) Read(id string) (*Integration, error) {
 	log.Printf("Getting Sym Integration: %s", id)
 	result := Integration{}
@@ -68,7 +78,7 @@ func (i *integrationClient) Find(name string, integrationType string) (*Integrat
 	log.Printf("Getting Sym Integration by name: %s", name)
 	var result []Integration
 
-	if err := i.HttpClient.Read(fmt.Sprintf("/entities/integrations?slug=%s&type=%s", name, integrationType), &result); err != nil {
+	if err := i.HttpClient.ReadAll(NewQuery().Slug(name).Type(integrationType).Path("/entities/integrations"), &result); err != nil {
 		return nil, err
 	}
 
@@ -80,6 +90,18 @@ func (i *integrationClient) Find(name string, integrationType string) (*Integrat
 	return &result[0], nil
 }
 
+func (i *integrationClient) List(query *Query) ([]Integration, error) {
+	log.Printf("Listing Sym Integrations")
+	var result []Integration
+
+	if err := i.HttpClient.ReadAll(query.Path("/entities/integrations"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Sym Integrations", len(result))
+	return result, nil
+}
+
 func (i *integrationClient) Update(integration Integration) (string, error) {
 	log.Printf("Updating Sym Integration: %v", integration)
 	result := Integration{}
@@ -96,6 +118,22 @@ func (i *integrationClient) Update(integration Integration) (string, error) {
 	return result.Id, nil
 }
 
+func (i *integrationClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching Sym Integration %s: %v", id, patch)
+	result := Integration{}
+
+	if _, err := i.HttpClient.Update(fmt.Sprintf("/entities/integrations/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Sym Integration was not updated")
+	}
+
+	log.Printf("Updated Sym Integration: %s", result.Id)
+	return result.Id, nil
+}
+
 func (i *integrationClient) Delete(id string) (string, error) {
 	log.Printf("Deleting Sym Integration: %s", id)
 
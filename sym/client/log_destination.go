@@ -7,9 +7,12 @@ import (
 
 type LogDestination struct {
 	Id            string   `json:"id,omitempty"`
+	Slug          string   `json:"slug,omitempty"`
 	Type          string   `json:"type"`
 	IntegrationId string   `json:"integration_id"`
 	Settings      Settings `json:"settings"`
+
+	Metadata
 }
 
 func (s LogDestination) String() string {
@@ -18,9 +21,12 @@ func (s LogDestination) String() string {
 
 type LogDestinationClient interface {
 	Create(destination LogDestination) (string, error)
+	Validate(destination LogDestination) error
 	Read(id string) (*LogDestination, error)
 	Find(name, destinationType string) (*LogDestination, error)
+	List(query *Query) ([]LogDestination, error)
 	Update(destination LogDestination) (string, error)
+	Patch(id string, patch Patch) (string, error)
 	Delete(id string) (string, error)
 }
 
@@ -50,6 +56,11 @@ func (l *logDestinationClient) Create(destination LogDestination) (string, error
 	return result.Id, nil
 }
 
+func (l *logDestinationClient) Validate(destination LogDestination) error {
+	log.Printf("Validating Sym LogDestination: %v", destination)
+	return l.HttpClient.Validate("/entities/log-destinations/validate", &destination)
+}
+
 func (l *logDestinationClient) Read(id string) (*LogDestination, error) {
 	log.Printf("Getting Sym LogDestination: %s", id)
 	result := LogDestination{}
@@ -66,7 +77,7 @@ func (l *logDestinationClient) Find(name, destinationType string) (*LogDestinati
 	log.Printf("Getting Sym Log Destination by type and name: %s %s", destinationType, name)
 	var result []LogDestination
 
-	if err := l.HttpClient.Read(fmt.Sprintf("/entities/log-destinations?slug=%s&type=%s", name, destinationType), &result); err != nil {
+	if err := l.HttpClient.ReadAll(NewQuery().Slug(name).Type(destinationType).Path("/entities/log-destinations"), &result); err != nil {
 		return nil, err
 	}
 
@@ -78,6 +89,18 @@ func (l *logDestinationClient) Find(name, destinationType string) (*LogDestinati
 	return &result[0], nil
 }
 
+func (l *logDestinationClient) List(query *Query) ([]LogDestination, error) {
+	log.Printf("Listing Sym LogDestinations")
+	var result []LogDestination
+
+	if err := l.HttpClient.ReadAll(query.Path("/entities/log-destinations"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Sym LogDestinations", len(result))
+	return result, nil
+}
+
 func (l *logDestinationClient) Update(destination LogDestination) (string, error) {
 	log.Printf("Updating Sym LogDestination: %v", destination)
 	result := LogDestination{}
@@ -94,6 +117,22 @@ func (l *logDestinationClient) Update(destination LogDestination) (string, error
 	return result.Id, nil
 }
 
+func (l *logDestinationClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching Sym LogDestination %s: %v", id, patch)
+	result := LogDestination{}
+
+	if _, err := l.HttpClient.Update(fmt.Sprintf("/entities/log-destinations/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Sym LogDestination was not updated")
+	}
+
+	log.Printf("Updated Sym LogDestination: %s", result.Id)
+	return result.Id, nil
+}
+
 func (l *logDestinationClient) Delete(id string) (string, error) {
 	log.Printf("Deleting Sym LogDestination: %s", id)
 
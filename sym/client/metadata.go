@@ -0,0 +1,21 @@
+package client
+
+// Metadata describes the most recent change made to a Sym entity, whether through this provider,
+// the Sym web app, or the Sym API. It is embedded in each entity type, and is read-only.
+type Metadata struct {
+	UpdatedAt string `json:"updated_at,omitempty"`
+	UpdatedBy string `json:"updated_by,omitempty"`
+
+	// ETag identifies the version of the entity that was read. It is taken from the ETag header of the
+	// response rather than the body. See ApiClient.WithETag.
+	ETag string `json:"-"`
+}
+
+// etagged is implemented by every entity type via the embedded Metadata.
+type etagged interface {
+	setETag(etag string)
+}
+
+func (m *Metadata) setETag(etag string) {
+	m.ETag = etag
+}
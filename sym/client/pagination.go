@@ -0,0 +1,137 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxPages bounds the number of pages ReadAll will follow, in case of a pagination loop.
+const maxPages = 10000
+
+// page is the envelope of a paginated list response from the Sym API.
+type page struct {
+	Results []json.RawMessage `json:"results"`
+	Next    string            `json:"next"`
+}
+
+// ReadAll reads every page of a list of entities into result, which must be a pointer to a slice.
+//
+// The next page is found from the "next" relation of the response's Link header, or from the `next` field
+// of a `{"results": [...], "next": "..."}` envelope. A plain JSON array without a Link header is a single page.
+// Relative next links are resolved against the URL of the current page. Next links to a different scheme or host
+// than the Sym API are rejected, so that the API token is never sent elsewhere.
+func (c *symHttpClient) ReadAll(path string, result interface{}) error {
+	var items []json.RawMessage
+	visited := make(map[string]bool)
+
+	for pageUrl := c.getUrl(path); pageUrl != ""; {
+		if visited[pageUrl] || len(visited) >= maxPages {
+			return fmt.Errorf("pagination of %s did not terminate", path)
+		}
+		visited[pageUrl] = true
+
+		body, header, err := c.send("GET", pageUrl, path, nil)
+		if err != nil {
+			return err
+		}
+
+		pageItems, next, err := parsePage([]byte(body), header)
+		if err != nil {
+			return err
+		}
+		items = append(items, pageItems...)
+
+		if pageUrl, err = resolveNext(c.apiUrl, pageUrl, next); err != nil {
+			return err
+		}
+	}
+
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+	b, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, result)
+}
+
+// parsePage returns the items in a page of a list response, and the link to the next page, if any.
+func parsePage(body []byte, header http.Header) ([]json.RawMessage, string, error) {
+	next := linkNext(header.Values("Link"))
+
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, "", err
+		}
+		return items, next, nil
+	}
+
+	var p page
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, "", err
+	}
+	if p.Next != "" {
+		next = p.Next
+	}
+	return p.Results, next, nil
+}
+
+// linkNext returns the target of the "next" relation in the given Link header values, as described
+// in RFC 8288, e.g. `<https://api.symops.com/api/v1/entities/flows?cursor=abc>; rel="next"`.
+func linkNext(links []string) string {
+	for _, header := range links {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range parts[1:] {
+				key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(key, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// resolveNext returns the absolute URL of the next page, or "" if there is none. It returns an error if the next
+// page is not on the same scheme and host as apiUrl.
+func resolveNext(apiUrl, current, next string) (string, error) {
+	if next == "" {
+		return "", nil
+	}
+
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(next)
+	if err != nil {
+		return "", err
+	}
+	resolved := base.ResolveReference(ref)
+
+	api, err := url.Parse(apiUrl)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(resolved.Scheme, api.Scheme) || !strings.EqualFold(resolved.Host, api.Host) {
+		return "", fmt.Errorf("next page %s is not on the Sym API at %s", resolved, apiUrl)
+	}
+	return resolved.String(), nil
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_symHttpClient_ReadAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		pages   map[string]func(w http.ResponseWriter, r *http.Request)
+		want    []string
+		wantErr string
+	}{
+		{
+			"single-page-array",
+			map[string]func(w http.ResponseWriter, r *http.Request){
+				"": func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, `[{"slug": "a"}, {"slug": "b"}]`)
+				},
+			},
+			[]string{"a", "b"},
+			"",
+		},
+		{
+			"link-header",
+			map[string]func(w http.ResponseWriter, r *http.Request){
+				"": func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Link", fmt.Sprintf(`<http://%s/entities/flows?cursor=2>; rel="next", <http://%s/entities/flows>; rel="first"`, r.Host, r.Host))
+					fmt.Fprint(w, `[{"slug": "a"}]`)
+				},
+				"2": func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Link", `</entities/flows?cursor=3>; rel="next"`)
+					fmt.Fprint(w, `[{"slug": "b"}]`)
+				},
+				"3": func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, `[{"slug": "c"}]`)
+				},
+			},
+			[]string{"a", "b", "c"},
+			"",
+		},
+		{
+			"envelope",
+			map[string]func(w http.ResponseWriter, r *http.Request){
+				"": func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, `{"results": [{"slug": "a"}], "next": "/entities/flows?cursor=2"}`)
+				},
+				"2": func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, `{"results": [{"slug": "b"}], "next": null}`)
+				},
+			},
+			[]string{"a", "b"},
+			"",
+		},
+		{
+			"empty",
+			map[string]func(w http.ResponseWriter, r *http.Request){
+				"": func(w http.ResponseWriter, r *http.Request) {
+					fmt.Fprint(w, `{"results": [], "next": null}`)
+				},
+			},
+			[]string{},
+			"",
+		},
+		{
+			"loop",
+			map[string]func(w http.ResponseWriter, r *http.Request){
+				"": func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Link", `</entities/flows>; rel="next"`)
+					fmt.Fprint(w, `[{"slug": "a"}]`)
+				},
+			},
+			nil,
+			"pagination of /entities/flows did not terminate",
+		},
+		{
+			"other-host",
+			map[string]func(w http.ResponseWriter, r *http.Request){
+				"": func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Link", `<https://example.com/entities/flows?cursor=2>; rel="next"`)
+					fmt.Fprint(w, `[{"slug": "a"}]`)
+				},
+			},
+			nil,
+			"next page https://example.com/entities/flows?cursor=2 is not on the Sym API at ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/entities/flows", r.URL.Path)
+				tt.pages[r.URL.Query().Get("cursor")](w, r)
+			}))
+			defer server.Close()
+
+			var result []Flow
+			err := NewSymHttpClient(server.URL, "token").ReadAll("/entities/flows", &result)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			slugs := make([]string, len(result))
+			for i, flow := range result {
+				slugs[i] = flow.Name
+			}
+			assert.Equal(t, tt.want, slugs)
+		})
+	}
+}
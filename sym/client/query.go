@@ -0,0 +1,64 @@
+package client
+
+import (
+	"net/url"
+)
+
+// Query builds the escaped query string used to filter a list of entities. Empty values are omitted,
+// so a Query may be built directly from optional attributes. A nil *Query has no filters, and setting a
+// filter on it returns a new Query.
+type Query struct {
+	values url.Values
+}
+
+// NewQuery returns an empty Query.
+func NewQuery() *Query {
+	return &Query{values: url.Values{}}
+}
+
+// Slug filters entities by slug.
+func (q *Query) Slug(slug string) *Query {
+	return q.set("slug", slug)
+}
+
+// Type filters entities by type, e.g. an Integration's type.
+func (q *Query) Type(entityType string) *Query {
+	return q.set("type", entityType)
+}
+
+// Label filters entities by label.
+func (q *Query) Label(label string) *Query {
+	return q.set("label", label)
+}
+
+// EnvironmentId filters entities by the ID of their Environment.
+func (q *Query) EnvironmentId(id string) *Query {
+	return q.set("environment_id", id)
+}
+
+// IntegrationId filters entities by the ID of their Integration.
+func (q *Query) IntegrationId(id string) *Query {
+	return q.set("integration_id", id)
+}
+
+func (q *Query) set(key, value string) *Query {
+	if value == "" {
+		return q
+	}
+	if q == nil {
+		q = NewQuery()
+	}
+	if q.values == nil {
+		q.values = url.Values{}
+	}
+	q.values.Set(key, value)
+	return q
+}
+
+// Path returns the given path with the query string appended.
+func (q *Query) Path(path string) string {
+	if q == nil || len(q.values) == 0 {
+		return path
+	}
+	return path + "?" + q.values.Encode()
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery_Path(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *Query
+		want  string
+	}{
+		{"nil", nil, "/entities/flows"},
+		{"nil-with-filter", (*Query)(nil).IntegrationId("integration-id"), "/entities/flows?integration_id=integration-id"},
+		{"nil-with-empty-filter", (*Query)(nil).Slug(""), "/entities/flows"},
+		{"zero-value", (&Query{}).Type("slack"), "/entities/flows?type=slack"},
+		{"empty", NewQuery(), "/entities/flows"},
+		{"empty-values-omitted", NewQuery().Slug("").Type(""), "/entities/flows"},
+		{"slug", NewQuery().Slug("prod"), "/entities/flows?slug=prod"},
+		{"slug-and-type", NewQuery().Slug("prod").Type("slack"), "/entities/flows?slug=prod&type=slack"},
+		{"special-characters", NewQuery().Slug("a&b#c+d e"), "/entities/flows?slug=a%26b%23c%2Bd+e"},
+		{
+			"filters",
+			NewQuery().Label("Prod Access").EnvironmentId("env-id").IntegrationId("integration-id"),
+			"/entities/flows?environment_id=env-id&integration_id=integration-id&label=Prod+Access",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.query.Path("/entities/flows"))
+		})
+	}
+}
+
+func TestQuery_Path_roundTrip(t *testing.T) {
+	slug := "a&b#c+d e=f?g"
+
+	parsed, err := url.Parse(NewQuery().Slug(slug).Type("slack").Path("/entities/integrations"))
+	assert.NoError(t, err)
+	assert.Equal(t, "/entities/integrations", parsed.Path)
+	assert.Equal(t, slug, parsed.Query().Get("slug"))
+	assert.Equal(t, "slack", parsed.Query().Get("type"))
+}
@@ -10,13 +10,18 @@ type Runtime struct {
 	Name      string `json:"slug"`
 	Label     string `json:"label,omitempty"`
 	ContextId string `json:"context_id,omitempty"`
+
+	Metadata
 }
 
 type RuntimeClient interface {
 	Create(runtime Runtime) (string, error)
+	Validate(runtime Runtime) error
 	Read(id string) (*Runtime, error)
 	Find(name string) (*Runtime, error)
+	List(query *Query) ([]Runtime, error)
 	Update(runtime Runtime) (string, error)
+	Patch(id string, patch Patch) (string, error)
 	Delete(id string) (string, error)
 }
 
@@ -46,6 +51,11 @@ func (c *runtimeClient) Create(runtime Runtime) (string, error) {
 	return result.Id, nil
 }
 
+func (c *runtimeClient) Validate(runtime Runtime) error {
+	log.Printf("Validating Runtime: %v", runtime)
+	return c.HttpClient.Validate("/entities/runtimes/validate", &runtime)
+}
+
 func (c *runtimeClient) Read(id string) (*Runtime, error) {
 	log.Printf("Getting Runtime: %s", id)
 	result := Runtime{}
@@ -62,7 +72,7 @@ func (c *runtimeClient) Find(name string) (*Runtime, error) {
 	log.Printf("Getting Runtime by name: %s", name)
 	var result []Runtime
 
-	if err := c.HttpClient.Read(fmt.Sprintf("/entities/runtimes?slug=%s", name), &result); err != nil {
+	if err := c.HttpClient.ReadAll(NewQuery().Slug(name).Path("/entities/runtimes"), &result); err != nil {
 		return nil, err
 	}
 
@@ -74,6 +84,18 @@ func (c *runtimeClient) Find(name string) (*Runtime, error) {
 	return &result[0], nil
 }
 
+func (c *runtimeClient) List(query *Query) ([]Runtime, error) {
+	log.Printf("Listing Sym Runtimes")
+	var result []Runtime
+
+	if err := c.HttpClient.ReadAll(query.Path("/entities/runtimes"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Sym Runtimes", len(result))
+	return result, nil
+}
+
 func (c *runtimeClient) Update(runtime Runtime) (string, error) {
 	log.Printf("Updating Runtime: %v", runtime)
 	result := Runtime{}
@@ -90,6 +112,22 @@ func (c *runtimeClient) Update(runtime Runtime) (string, error) {
 	return result.Id, nil
 }
 
+func (c *runtimeClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching Runtime %s: %v", id, patch)
+	result := Runtime{}
+
+	if _, err := c.HttpClient.Update(fmt.Sprintf("/entities/runtimes/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Runtime was not updated")
+	}
+
+	log.Printf("Updated Runtime: %s", result.Id)
+	return result.Id, nil
+}
+
 func (c *runtimeClient) Delete(id string) (string, error) {
 	log.Printf("Deleting Runtime: %s", id)
 
@@ -11,14 +11,29 @@ type Secret struct {
 	SourceId string   `json:"source_id"`
 	Label    string   `json:"label,omitempty"`
 	Settings Settings `json:"settings"`
+
+	Metadata
+}
+
+// secretValue is the payload setting the value of a Secret in its source.
+type secretValue struct {
+	Value string `json:"value"`
 }
 
+func (secretValue) sensitive() {}
+
 type SecretClient interface {
 	Create(secret Secret) (string, error)
+	Validate(secret Secret) error
 	Read(id string) (*Secret, error)
 	Find(slug string) (*Secret, error)
+	List(query *Query) ([]Secret, error)
 	Update(secret Secret) (string, error)
+	Patch(id string, patch Patch) (string, error)
 	Delete(id string) (string, error)
+
+	// SetValue stores the value of the Secret in its source, e.g. AWS Secrets Manager.
+	SetValue(id string, value string) error
 }
 
 func NewSecretClient(httpClient SymHttpClient) SecretClient {
@@ -47,6 +62,11 @@ func (c *secretClient) Create(secret Secret) (string, error) {
 	return result.Id, nil
 }
 
+func (c *secretClient) Validate(secret Secret) error {
+	log.Printf("Validating Secret: %v", secret)
+	return c.HttpClient.Validate("/entities/secrets/validate", &secret)
+}
+
 func (c *secretClient) Read(id string) (*Secret, error) {
 	log.Printf("Getting Secret: %s", id)
 	result := Secret{}
@@ -63,7 +83,7 @@ func (c *secretClient) Find(slug string) (*Secret, error) {
 	log.Printf("Getting Secret by slug: %s", slug)
 	var result []Secret
 
-	if err := c.HttpClient.Read(fmt.Sprintf("/entities/secrets?slug=%s", slug), &result); err != nil {
+	if err := c.HttpClient.ReadAll(NewQuery().Slug(slug).Path("/entities/secrets"), &result); err != nil {
 		return nil, err
 	}
 
@@ -75,6 +95,18 @@ func (c *secretClient) Find(slug string) (*Secret, error) {
 	return &result[0], nil
 }
 
+func (c *secretClient) List(query *Query) ([]Secret, error) {
+	log.Printf("Listing Sym Secrets")
+	var result []Secret
+
+	if err := c.HttpClient.ReadAll(query.Path("/entities/secrets"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Sym Secrets", len(result))
+	return result, nil
+}
+
 func (c *secretClient) Update(secret Secret) (string, error) {
 	log.Printf("Updating Secret: %v", secret)
 	result := Secret{}
@@ -91,6 +123,22 @@ func (c *secretClient) Update(secret Secret) (string, error) {
 	return result.Id, nil
 }
 
+func (c *secretClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching Secret %s: %v", id, patch)
+	result := Secret{}
+
+	if _, err := c.HttpClient.Update(fmt.Sprintf("/entities/secrets/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Secret was not updated")
+	}
+
+	log.Printf("Updated Secret: %s", result.Id)
+	return result.Id, nil
+}
+
 func (c *secretClient) Delete(id string) (string, error) {
 	log.Printf("Deleting Secret: %s", id)
 
@@ -100,3 +148,14 @@ func (c *secretClient) Delete(id string) (string, error) {
 
 	return id, nil
 }
+
+func (c *secretClient) SetValue(id string, value string) error {
+	log.Printf("Setting value of Secret: %s", id)
+
+	if _, err := c.HttpClient.Do("PUT", fmt.Sprintf("/entities/secrets/%s/value", id), secretValue{Value: value}); err != nil {
+		return err
+	}
+
+	log.Printf("Set value of Secret: %s", id)
+	return nil
+}
@@ -11,14 +11,19 @@ type Secrets struct {
 	Name     string   `json:"slug"`
 	Settings Settings `json:"settings"`
 	Label    string   `json:"label,omitempty"`
+
+	Metadata
 }
 
 type SecretsClient interface {
 	Create(secrets Secrets) (string, error)
+	Validate(secrets Secrets) error
 	Read(id string) (*Secrets, error)
 	Update(secrets Secrets) (string, error)
+	Patch(id string, patch Patch) (string, error)
 	Delete(id string) (string, error)
 	Find(name string, secretsType string) (*Secrets, error)
+	List(query *Query) ([]Secrets, error)
 }
 
 func NewSecretsClient(httpClient SymHttpClient) SecretsClient {
@@ -47,6 +52,11 @@ func (c *secretsClient) Create(secrets Secrets) (string, error) {
 	return result.Id, nil
 }
 
+func (c *secretsClient) Validate(secrets Secrets) error {
+	log.Printf("Validating Secrets: %v", secrets)
+	return c.HttpClient.Validate("/entities/secret-sources/validate", &secrets)
+}
+
 func (c *secretsClient) Read(id string) (*Secrets, error) {
 	log.Printf("Getting Secrets: %s", id)
 	result := Secrets{}
@@ -75,6 +85,22 @@ func (c *secretsClient) Update(secrets Secrets) (string, error) {
 	return result.Id, nil
 }
 
+func (c *secretsClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching Secrets %s: %v", id, patch)
+	result := Secrets{}
+
+	if _, err := c.HttpClient.Update(fmt.Sprintf("/entities/secret-sources/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Secrets was not updated")
+	}
+
+	log.Printf("Updated Secrets: %s", result.Id)
+	return result.Id, nil
+}
+
 func (c *secretsClient) Delete(id string) (string, error) {
 	log.Printf("Deleting Secrets: %s", id)
 
@@ -89,7 +115,7 @@ func (i *secretsClient) Find(name string, secretsType string) (*Secrets, error)
 	log.Printf("Getting Sym Secrets by name: %s and type: %s", name, secretsType)
 	var result []Secrets
 
-	if err := i.HttpClient.Read(fmt.Sprintf("/entities/secret-sources?slug=%s&type=%s", name, secretsType), &result); err != nil {
+	if err := i.HttpClient.ReadAll(NewQuery().Slug(name).Type(secretsType).Path("/entities/secret-sources"), &result); err != nil {
 		return nil, err
 	}
 
@@ -100,3 +126,15 @@ func (i *secretsClient) Find(name string, secretsType string) (*Secrets, error)
 	log.Printf("Got Sym Secrets by name: %s and type: %s (%s)", name, secretsType, result[0].Id)
 	return &result[0], nil
 }
+
+func (i *secretsClient) List(query *Query) ([]Secrets, error) {
+	log.Printf("Listing Secrets")
+	var result []Secrets
+
+	if err := i.HttpClient.ReadAll(query.Path("/entities/secret-sources"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Secrets", len(result))
+	return result, nil
+}
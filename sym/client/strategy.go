@@ -1,41 +1,71 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 )
 
 type Tags map[string]string
 
+// StrategyTarget is a Target of a Strategy, with tags that the Strategy's implementation may use to
+// handle each Target differently.
 type StrategyTarget struct {
 	TargetId string `json:"target_id"`
 	Tags     Tags   `json:"tags"`
 }
 
+// UnmarshalJSON also accepts a bare Target ID, which is how Strategy Targets without tags were returned
+// by earlier versions of the Sym API.
+func (s *StrategyTarget) UnmarshalJSON(b []byte) error {
+	var id string
+	if err := json.Unmarshal(b, &id); err == nil {
+		*s = StrategyTarget{TargetId: id, Tags: Tags{}}
+		return nil
+	}
+
+	type strategyTarget StrategyTarget
+	return json.Unmarshal(b, (*strategyTarget)(s))
+}
+
 func (s StrategyTarget) String() string {
 	return fmt.Sprintf("{target_id=%s, tags=%v}", s.TargetId, s.Tags)
 }
 
 type Strategy struct {
-	Id             string   `json:"id,omitempty"`
-	Type           string   `json:"type"`
-	IntegrationId  string   `json:"integration_id"`
-	Targets        []string `json:"targets"`
-	Settings       Settings `json:"settings"`
-	Name           string   `json:"slug"`
-	Label          string   `json:"label,omitempty"`
-	Implementation string   `json:"implementation,omitempty"`
+	Id             string           `json:"id,omitempty"`
+	Type           string           `json:"type"`
+	IntegrationId  string           `json:"integration_id"`
+	Targets        []StrategyTarget `json:"targets"`
+	Settings       Settings         `json:"settings"`
+	Name           string           `json:"slug"`
+	Label          string           `json:"label,omitempty"`
+	Implementation string           `json:"implementation,omitempty"`
+
+	Metadata
 }
 
 func (s Strategy) String() string {
 	return fmt.Sprintf("{id=%s, type=%s, name=%s, label=%s, integration_id=%s, targets=%v, implementation=%s}", s.Id, s.Type, s.Name, s.Label, s.IntegrationId, s.Targets, s.Implementation)
 }
 
+// TargetIds returns the IDs of the Strategy's Targets.
+func (s Strategy) TargetIds() []string {
+	ids := make([]string, len(s.Targets))
+	for i, target := range s.Targets {
+		ids[i] = target.TargetId
+	}
+	return ids
+}
+
 type StrategyClient interface {
 	Create(strategy Strategy) (string, error)
+	Validate(strategy Strategy) error
 	Read(id string) (*Strategy, error)
 	Find(name, strategyType string) (*Strategy, error)
+	List(query *Query) ([]Strategy, error)
 	Update(strategy Strategy) (string, error)
+	Patch(id string, patch Patch) (string, error)
 	Delete(id string) (string, error)
 }
 
@@ -64,6 +94,11 @@ func (c *strategyClient) Create(strategy Strategy) (string, error) {
 	return result.Id, nil
 }
 
+func (c *strategyClient) Validate(strategy Strategy) error {
+	log.Printf("Validating Sym Strategy: %v", strategy)
+	return c.HttpClient.Validate("/entities/access-strategies/validate", &strategy)
+}
+
 func (c *strategyClient) Read(id string) (*Strategy, error) {
 	log.Printf("Getting Sym Strategy: %s", id)
 	result := Strategy{}
@@ -79,7 +114,7 @@ func (c *strategyClient) Find(name, strategyType string) (*Strategy, error) {
 	log.Printf("Getting Sym Strategy by type %s and name %s", strategyType, name)
 	var result []Strategy
 
-	if err := c.HttpClient.Read(fmt.Sprintf("/entities/access-strategies?slug=%s&type=%s", name, strategyType), &result); err != nil {
+	if err := c.HttpClient.ReadAll(NewQuery().Slug(name).Type(strategyType).Path("/entities/access-strategies"), &result); err != nil {
 		return nil, err
 	}
 
@@ -91,6 +126,18 @@ func (c *strategyClient) Find(name, strategyType string) (*Strategy, error) {
 	return &result[0], nil
 }
 
+func (c *strategyClient) List(query *Query) ([]Strategy, error) {
+	log.Printf("Listing Sym Strategies")
+	var result []Strategy
+
+	if err := c.HttpClient.ReadAll(query.Path("/entities/access-strategies"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Sym Strategies", len(result))
+	return result, nil
+}
+
 func (c *strategyClient) Update(strategy Strategy) (string, error) {
 	log.Printf("Updating Sym Strategy: %v", strategy)
 	result := Strategy{}
@@ -107,6 +154,22 @@ func (c *strategyClient) Update(strategy Strategy) (string, error) {
 	return result.Id, nil
 }
 
+func (c *strategyClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching Sym Strategy %s: %v", id, patch)
+	result := Strategy{}
+
+	if _, err := c.HttpClient.Update(fmt.Sprintf("/entities/access-strategies/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Sym Strategy was not updated")
+	}
+
+	log.Printf("Updated Sym Strategy: %s", result.Id)
+	return result.Id, nil
+}
+
 func (c *strategyClient) Delete(id string) (string, error) {
 	log.Printf("Deleting Sym Strategy: %s", id)
 
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrategyTarget_UnmarshalJSON(t *testing.T) {
+	var strategy Strategy
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"targets": [
+			"target-a",
+			{"target_id": "target-b", "tags": {"risk": "high"}}
+		]
+	}`), &strategy))
+
+	assert.Equal(t, []StrategyTarget{
+		{TargetId: "target-a", Tags: Tags{}},
+		{TargetId: "target-b", Tags: Tags{"risk": "high"}},
+	}, strategy.Targets)
+	assert.Equal(t, []string{"target-a", "target-b"}, strategy.TargetIds())
+
+	var target StrategyTarget
+	assert.Error(t, json.Unmarshal([]byte(`42`), &target))
+}
+
+func TestStrategy_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(Strategy{Targets: []StrategyTarget{{TargetId: "target-a", Tags: Tags{"risk": "low"}}}})
+	require.NoError(t, err)
+
+	var encoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &encoded))
+	assert.Equal(t, []interface{}{map[string]interface{}{"target_id": "target-a", "tags": map[string]interface{}{"risk": "low"}}}, encoded["targets"])
+}
@@ -12,6 +12,8 @@ type Target struct {
 	Label         string   `json:"label,omitempty"`
 	FieldBindings []string `json:"field_bindings,omitempty"`
 	Settings      Settings `json:"settings"`
+
+	Metadata
 }
 
 func (s Target) String() string {
@@ -28,9 +30,12 @@ func (s Target) String() string {
 
 type TargetClient interface {
 	Create(target Target) (string, error)
+	Validate(target Target) error
 	Read(id string) (*Target, error)
 	Find(name string, targetType string) (*Target, error)
+	List(query *Query) ([]Target, error)
 	Update(target Target) (string, error)
+	Patch(id string, patch Patch) (string, error)
 	Delete(id string) (string, error)
 }
 
@@ -60,6 +65,11 @@ func (c *targetClient) Create(target Target) (string, error) {
 	return result.Id, nil
 }
 
+func (c *targetClient) Validate(target Target) error {
+	log.Printf("Validating Sym Target: %v", target)
+	return c.HttpClient.Validate("/entities/access-targets/validate", &target)
+}
+
 func (c *targetClient) Read(id string) (*Target, error) {
 	log.Printf("Getting Sym Target: %s", id)
 	result := Target{}
@@ -76,7 +86,7 @@ func (c *targetClient) Find(name, targetType string) (*Target, error) {
 	log.Printf("Getting Target by name: %s", name)
 	var result []Target
 
-	if err := c.HttpClient.Read(fmt.Sprintf("/entities/access-targets?slug=%s&type=%s", name, targetType), &result); err != nil {
+	if err := c.HttpClient.ReadAll(NewQuery().Slug(name).Type(targetType).Path("/entities/access-targets"), &result); err != nil {
 		return nil, err
 	}
 
@@ -88,6 +98,18 @@ func (c *targetClient) Find(name, targetType string) (*Target, error) {
 	return &result[0], nil
 }
 
+func (c *targetClient) List(query *Query) ([]Target, error) {
+	log.Printf("Listing Sym Targets")
+	var result []Target
+
+	if err := c.HttpClient.ReadAll(query.Path("/entities/access-targets"), &result); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Listed %d Sym Targets", len(result))
+	return result, nil
+}
+
 func (c *targetClient) Update(target Target) (string, error) {
 	log.Printf("Updating Sym Target: %v", target)
 	result := Target{}
@@ -104,6 +126,22 @@ func (c *targetClient) Update(target Target) (string, error) {
 	return result.Id, nil
 }
 
+func (c *targetClient) Patch(id string, patch Patch) (string, error) {
+	log.Printf("Patching Sym Target %s: %v", id, patch)
+	result := Target{}
+
+	if _, err := c.HttpClient.Update(fmt.Sprintf("/entities/access-targets/%s", id), patch, &result); err != nil {
+		return "", err
+	}
+
+	if result.Id == "" {
+		return "", fmt.Errorf("response indicates Sym Target was not updated")
+	}
+
+	log.Printf("Updated Sym Target: %s", result.Id)
+	return result.Id, nil
+}
+
 func (c *targetClient) Delete(id string) (string, error) {
 	log.Printf("Deleting Sym Target: %s", id)
 
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// TokenRequest asks the Sym API to issue a token for a Bot.
+type TokenRequest struct {
+	BotId string `json:"bot_id"`
+	// Expiry is the lifetime of the token, in seconds.
+	Expiry int64    `json:"expiry"`
+	Label  string   `json:"label,omitempty"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// Token is a token issued for a Bot. The AccessToken is only returned when the token is issued.
+type Token struct {
+	Id          string   `json:"id"`
+	AccessToken string   `json:"access_token"`
+	ExpiresAt   string   `json:"expires_at"`
+	Scopes      []string `json:"scopes"`
+}
+
+// String omits the AccessToken, so that the token is never written to the logs.
+func (t Token) String() string {
+	return fmt.Sprintf("{id=%s, expires_at=%s, scopes=%v}", t.Id, t.ExpiresAt, t.Scopes)
+}
+
+type TokenClient interface {
+	Issue(request TokenRequest) (*Token, error)
+	Revoke(id string) error
+}
+
+func NewTokenClient(httpClient SymHttpClient) TokenClient {
+	return &tokenClient{
+		HttpClient: httpClient,
+	}
+}
+
+type tokenClient struct {
+	HttpClient SymHttpClient
+}
+
+func (c *tokenClient) Issue(request TokenRequest) (*Token, error) {
+	log.Printf("Issuing Token for Bot: %s", request.BotId)
+	result := Token{}
+
+	// Tokens are not entities, so the request is sent with Do rather than Create, which would add annotations.
+	body, err := c.HttpClient.Do("POST", "/tokens", &request)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		return nil, err
+	}
+
+	if result.Id == "" || result.AccessToken == "" {
+		return nil, fmt.Errorf("response indicates Token was not issued")
+	}
+
+	log.Printf("Issued Token: %s", result.Id)
+	return &result, nil
+}
+
+func (c *tokenClient) Revoke(id string) error {
+	log.Printf("Revoking Token: %s", id)
+	return c.HttpClient.Delete(fmt.Sprintf("/tokens/%s", id))
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_tokenClient(t *testing.T) {
+	var issued map[string]interface{}
+	var revoked string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			assert.Equal(t, "/tokens", r.URL.Path)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&issued))
+			fmt.Fprint(w, `{"id": "token-id", "access_token": "secret-token", "expires_at": "2024-01-01T01:00:00Z"}`)
+		case "DELETE":
+			revoked = r.URL.Path
+		}
+	}))
+	defer server.Close()
+
+	c := newApiClient(&annotatedHttpClient{SymHttpClient: NewSymHttpClient(server.URL, "jwt")})
+
+	token, err := c.Token.Issue(TokenRequest{BotId: "bot-id", Expiry: 3600, Scopes: []string{"flows:read"}})
+	require.NoError(t, err)
+	// The token request is not annotated like an entity.
+	assert.Equal(t, map[string]interface{}{"bot_id": "bot-id", "expiry": float64(3600), "scopes": []interface{}{"flows:read"}}, issued)
+	assert.Equal(t, "secret-token", token.AccessToken)
+	assert.NotContains(t, fmt.Sprint(token), "secret-token")
+
+	require.NoError(t, c.Token.Revoke("token-id"))
+	assert.Equal(t, "/tokens/token-id", revoked)
+}
@@ -0,0 +1,143 @@
+// Package export implements the `export` subcommand of the provider binary, which generates
+// Terraform configuration for the existing Sym entities in an org so they can be imported.
+package export
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+const usage = `Usage: terraform-provider-sym export -org ORG [-jwt-env-var NAME] [-out DIR]
+
+Reads every entity in a Sym org and writes Terraform configuration for them to DIR:
+
+  main.tf     a resource block for each entity, with references between them resolved
+  imports.tf  an import block for each resource, for use with Terraform >= 1.5
+  impls/      the implementation of each Flow, Flows Filter, and Strategy
+
+Options:
+`
+
+// Run executes the export subcommand with the given command line arguments, excluding "export".
+func Run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+
+	org := flags.String("org", "", "Your Sym Org ID (required)")
+	jwtEnvVar := flags.String("jwt-env-var", "", "Environment variable storing your Sym Bot Token")
+	out := flags.String("out", "sym-export", "Directory to write the generated configuration to. It must not already contain files.")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *org == "" {
+		flags.Usage()
+		return fmt.Errorf("the -org flag is required")
+	}
+
+	cfg, err := utils.GetDefaultConfig(*jwtEnvVar)
+	if err != nil {
+		return err
+	}
+	if err := cfg.ValidateOrg(*org); err != nil {
+		return err
+	}
+
+	e, err := fetchEntities(client.New(cfg.AuthToken.AccessToken))
+	if err != nil {
+		return err
+	}
+
+	files := generate(*org, e)
+	if err := writeFiles(*out, files); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Exported %d files to %s\n", len(files), *out)
+	return nil
+}
+
+// fetchEntities lists every entity in the org.
+func fetchEntities(c *client.ApiClient) (*entities, error) {
+	var (
+		e   entities
+		err error
+	)
+
+	if e.Integrations, err = c.Integration.List(nil); err != nil {
+		return nil, fmt.Errorf("unable to list Integrations: %w", err)
+	}
+	if e.SecretSources, err = c.Secrets.List(nil); err != nil {
+		return nil, fmt.Errorf("unable to list Secrets sources: %w", err)
+	}
+	if e.Secrets, err = c.Secret.List(nil); err != nil {
+		return nil, fmt.Errorf("unable to list Secrets: %w", err)
+	}
+	if e.Targets, err = c.Target.List(nil); err != nil {
+		return nil, fmt.Errorf("unable to list Targets: %w", err)
+	}
+	if e.Strategies, err = c.Strategy.List(nil); err != nil {
+		return nil, fmt.Errorf("unable to list Strategies: %w", err)
+	}
+	if e.Runtimes, err = c.Runtime.List(nil); err != nil {
+		return nil, fmt.Errorf("unable to list Runtimes: %w", err)
+	}
+	if e.ErrorLoggers, err = c.ErrorLogger.List(nil); err != nil {
+		return nil, fmt.Errorf("unable to list Error Loggers: %w", err)
+	}
+	if e.LogDestinations, err = c.LogDestination.List(nil); err != nil {
+		return nil, fmt.Errorf("unable to list Log Destinations: %w", err)
+	}
+	if e.Environments, err = c.Environment.List(nil); err != nil {
+		return nil, fmt.Errorf("unable to list Environments: %w", err)
+	}
+	if e.Flows, err = c.Flow.List(nil); err != nil {
+		return nil, fmt.Errorf("unable to list Flows: %w", err)
+	}
+
+	// An org has at most one Flows Filter, which may not exist.
+	if e.FlowsFilter, err = c.FlowsFilter.Read(); err != nil {
+		if !utils.IsNotFoundError(err) {
+			return nil, fmt.Errorf("unable to read Flows Filter: %w", err)
+		}
+		e.FlowsFilter = nil
+	}
+
+	return &e, nil
+}
+
+// writeFiles writes the generated files to the output directory, which must be empty or not exist.
+func writeFiles(dir string, files map[string][]byte) error {
+	if existing, err := os.ReadDir(dir); err == nil && len(existing) > 0 {
+		return fmt.Errorf("the output directory %s is not empty", dir)
+	}
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		fullPath := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fullPath, files[path], 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
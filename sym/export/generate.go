@@ -0,0 +1,514 @@
+package export
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/provider"
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+const (
+	resourcesFile = "main.tf"
+	importsFile   = "imports.tf"
+	implsDir      = "impls"
+)
+
+// entities holds every Sym entity in an org, as returned by the Sym API.
+type entities struct {
+	Integrations    []client.Integration
+	SecretSources   []client.Secrets
+	Secrets         []client.Secret
+	Targets         []client.Target
+	Strategies      []client.Strategy
+	Runtimes        []client.Runtime
+	ErrorLoggers    []client.ErrorLogger
+	LogDestinations []client.LogDestination
+	Environments    []client.Environment
+	Flows           []client.Flow
+	FlowsFilter     *client.FlowsFilter
+}
+
+// generator builds the Terraform configuration for a set of entities.
+type generator struct {
+	// addresses maps each entity's ID to its Terraform address, e.g. "sym_environment.prod".
+	addresses map[string]string
+
+	// usedNames tracks the Terraform names already used for each resource type.
+	usedNames map[string]map[string]bool
+
+	resources *hclwrite.File
+	imports   *hclwrite.File
+
+	// files maps the path of each extracted implementation file to its contents.
+	files map[string]string
+}
+
+// generate returns the contents of each file of the Terraform configuration for the given
+// entities, keyed by path relative to the output directory.
+func generate(org string, e *entities) map[string][]byte {
+	g := &generator{
+		addresses: map[string]string{},
+		usedNames: map[string]map[string]bool{},
+		resources: hclwrite.NewEmptyFile(),
+		imports:   hclwrite.NewEmptyFile(),
+		files:     map[string]string{},
+	}
+
+	g.writeProvider(org)
+
+	// Assign every address up front, so references can be resolved regardless of the order resources are written in.
+	for _, i := range e.Integrations {
+		g.assign(i.Id, "sym_integration", i.Name)
+	}
+	for _, s := range e.SecretSources {
+		g.assign(s.Id, "sym_secrets", s.Name)
+	}
+	for _, s := range e.Secrets {
+		g.assign(s.Id, "sym_secret", s.Path)
+	}
+	for _, t := range e.Targets {
+		g.assign(t.Id, "sym_target", t.Name)
+	}
+	for _, s := range e.Strategies {
+		g.assign(s.Id, "sym_strategy", s.Name)
+	}
+	for _, r := range e.Runtimes {
+		g.assign(r.Id, "sym_runtime", r.Name)
+	}
+	for _, l := range e.ErrorLoggers {
+		g.assign(l.Id, "sym_error_logger", l.Destination)
+	}
+	for _, l := range e.LogDestinations {
+		g.assign(l.Id, "sym_log_destination", l.Type)
+	}
+	for _, env := range e.Environments {
+		g.assign(env.Id, "sym_environment", env.Name)
+	}
+	for _, f := range e.Flows {
+		g.assign(f.Id, "sym_flow", f.Name)
+	}
+	if e.FlowsFilter != nil {
+		g.assign(e.FlowsFilter.Id, "sym_flows_filter", "this")
+	}
+
+	for _, i := range e.Integrations {
+		g.writeIntegration(i)
+	}
+	for _, s := range e.SecretSources {
+		g.writeSecretSource(s)
+	}
+	for _, s := range e.Secrets {
+		g.writeSecret(s)
+	}
+	for _, t := range e.Targets {
+		g.writeTarget(t)
+	}
+	for _, s := range e.Strategies {
+		g.writeStrategy(s)
+	}
+	for _, r := range e.Runtimes {
+		g.writeRuntime(r)
+	}
+	for _, l := range e.ErrorLoggers {
+		g.writeErrorLogger(l)
+	}
+	for _, l := range e.LogDestinations {
+		g.writeLogDestination(l)
+	}
+	for _, env := range e.Environments {
+		g.writeEnvironment(env)
+	}
+	for _, f := range e.Flows {
+		g.writeFlow(f)
+	}
+	if e.FlowsFilter != nil {
+		g.writeFlowsFilter(*e.FlowsFilter)
+	}
+
+	output := map[string][]byte{
+		resourcesFile: hclwrite.Format(g.resources.Bytes()),
+		importsFile:   hclwrite.Format(g.imports.Bytes()),
+	}
+	for path, contents := range g.files {
+		output[path] = []byte(contents)
+	}
+	return output
+}
+
+// Naming and references /////////////////////////
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// terraformName converts a Sym slug (or other identifying value) into a Terraform resource name.
+func terraformName(value string) string {
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(value), "_"), "_")
+	if name == "" {
+		return "this"
+	}
+	if !hclsyntax.ValidIdentifier(name) || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// assign records a unique Terraform address for the entity with the given ID.
+func (g *generator) assign(id, resourceType, value string) string {
+	if g.usedNames[resourceType] == nil {
+		g.usedNames[resourceType] = map[string]bool{}
+	}
+
+	base := terraformName(value)
+	name := base
+	for i := 2; g.usedNames[resourceType][name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	g.usedNames[resourceType][name] = true
+
+	address := resourceType + "." + name
+	g.addresses[id] = address
+	return address
+}
+
+// resolve replaces any string equal to the ID of an exported entity with a reference to that
+// entity's `id` attribute, including within slices and maps.
+func (g *generator) resolve(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if address, ok := g.addresses[v]; ok {
+			return reference(address + ".id")
+		}
+		return v
+	case []string:
+		resolved := make([]interface{}, len(v))
+		for i := range v {
+			resolved[i] = g.resolve(v[i])
+		}
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i := range v {
+			resolved[i] = g.resolve(v[i])
+		}
+		return resolved
+	case map[string]string:
+		resolved := make(map[string]interface{}, len(v))
+		for k := range v {
+			resolved[k] = g.resolve(v[k])
+		}
+		return resolved
+	case client.Settings:
+		return g.resolve(map[string]string(v))
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for k := range v {
+			resolved[k] = g.resolve(v[k])
+		}
+		return resolved
+	default:
+		return v
+	}
+}
+
+// Value helpers //////////////////////////////////
+
+// optional returns nil for empty strings, so the attribute is omitted.
+func optional(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// optionalRef is like optional, but resolves the value to a reference if possible.
+func (g *generator) optionalRef(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return g.resolve(s)
+}
+
+// optionalMap returns nil for empty maps, so the attribute is omitted.
+func (g *generator) optionalMap(m map[string]string) interface{} {
+	if len(m) == 0 {
+		return nil
+	}
+	return g.resolve(m)
+}
+
+// optionalList returns nil for empty lists, so the attribute is omitted.
+func (g *generator) optionalList(l []string) interface{} {
+	if len(l) == 0 {
+		return nil
+	}
+	return g.resolve(l)
+}
+
+// writeImpl records an implementation file and returns the file() call that reads it.
+func (g *generator) writeImpl(name, contents string) fileCall {
+	path := g.implPath(name)
+	g.files[path] = contents
+	return fileCall(path)
+}
+
+// implPath returns a unique path for an implementation file with the given name, in the same way that assign
+// makes Terraform names unique, so that no two resources read the same file.
+func (g *generator) implPath(name string) string {
+	path := implsDir + "/" + name + ".py"
+	for i := 2; ; i++ {
+		if _, ok := g.files[path]; !ok {
+			return path
+		}
+		path = fmt.Sprintf("%s/%s_%d.py", implsDir, name, i)
+	}
+}
+
+// decodeImpl decodes a base64-encoded implementation from the Sym API.
+func decodeImpl(impl string) string {
+	if decoded, err := base64.StdEncoding.DecodeString(impl); err == nil {
+		return string(decoded)
+	}
+	return impl
+}
+
+// Blocks /////////////////////////////////////////
+
+func (g *generator) writeProvider(org string) {
+	terraform := g.resources.Body().AppendNewBlock("terraform", nil).Body()
+	requiredProviders := terraform.AppendNewBlock("required_providers", nil).Body()
+	requiredProviders.SetAttributeRaw("sym", tokensFor(map[string]interface{}{"source": "symopsio/sym"}))
+	g.resources.Body().AppendNewline()
+
+	provider := g.resources.Body().AppendNewBlock("provider", []string{"sym"}).Body()
+	provider.SetAttributeValue("org", cty.StringVal(org))
+}
+
+// writeResource appends a resource block with the given attributes for the entity with the given ID,
+// and an import block for it.
+func (g *generator) writeResource(id, importID string, attributes []attribute) *hclwrite.Body {
+	address := g.addresses[id]
+	parts := strings.SplitN(address, ".", 2)
+
+	g.resources.Body().AppendNewline()
+	body := g.resources.Body().AppendNewBlock("resource", parts).Body()
+	setAttributes(body, attributes)
+
+	block := g.imports.Body().AppendNewBlock("import", nil).Body()
+	block.SetAttributeTraversal("to", traversalFor(address))
+	block.SetAttributeValue("id", cty.StringVal(importID))
+	g.imports.Body().AppendNewline()
+
+	return body
+}
+
+func (g *generator) writeIntegration(i client.Integration) {
+	g.writeResource(i.Id, i.Type+":"+i.Name, []attribute{
+		{"type", i.Type},
+		{"name", i.Name},
+		{"label", optional(i.Label)},
+		{"external_id", optional(i.ExternalId)},
+		{"settings", g.optionalMap(i.Settings)},
+	})
+}
+
+func (g *generator) writeSecretSource(s client.Secrets) {
+	g.writeResource(s.Id, s.Type+":"+s.Name, []attribute{
+		{"type", s.Type},
+		{"name", s.Name},
+		{"label", optional(s.Label)},
+		{"settings", g.optionalMap(s.Settings)},
+	})
+}
+
+func (g *generator) writeSecret(s client.Secret) {
+	g.writeResource(s.Id, s.SourceId+":"+s.Path, []attribute{
+		{"path", s.Path},
+		{"source_id", g.resolve(s.SourceId)},
+		{"label", optional(s.Label)},
+		{"settings", g.optionalMap(s.Settings)},
+	})
+}
+
+func (g *generator) writeTarget(t client.Target) {
+	g.writeResource(t.Id, t.Type+":"+t.Name, []attribute{
+		{"type", t.Type},
+		{"name", t.Name},
+		{"label", optional(t.Label)},
+		{"field_bindings", g.optionalList(t.FieldBindings)},
+		{"settings", g.optionalMap(t.Settings)},
+	})
+}
+
+func (g *generator) writeStrategy(s client.Strategy) {
+	// sym_strategy reads its implementation from a path relative to the working directory, rather than file contents.
+	var implementation interface{}
+	if impl := utils.ParseRemoteImpl(s.Implementation); impl != "" {
+		path := g.implPath(strings.SplitN(g.addresses[s.Id], ".", 2)[1] + "_strategy")
+		g.files[path] = impl
+		implementation = path
+	}
+
+	body := g.writeResource(s.Id, s.Type+":"+s.Name, []attribute{
+		{"type", s.Type},
+		{"name", s.Name},
+		{"label", optional(s.Label)},
+		{"integration_id", g.optionalRef(s.IntegrationId)},
+		{"settings", g.optionalMap(s.Settings)},
+		{"implementation", implementation},
+	})
+
+	for _, target := range s.Targets {
+		body.AppendNewline()
+		setAttributes(body.AppendNewBlock("target", nil).Body(), []attribute{
+			{"id", g.resolve(target.TargetId)},
+			{"tags", g.optionalMap(target.Tags)},
+		})
+	}
+}
+
+func (g *generator) writeRuntime(r client.Runtime) {
+	g.writeResource(r.Id, r.Name, []attribute{
+		{"name", r.Name},
+		{"label", optional(r.Label)},
+		{"context_id", g.optionalRef(r.ContextId)},
+	})
+}
+
+func (g *generator) writeErrorLogger(l client.ErrorLogger) {
+	g.writeResource(l.Id, orId(l.Slug, l.Id), []attribute{
+		{"integration_id", g.resolve(l.IntegrationId)},
+		{"destination", l.Destination},
+	})
+}
+
+func (g *generator) writeLogDestination(l client.LogDestination) {
+	importID := l.Id
+	if l.Slug != "" {
+		importID = l.Type + ":" + l.Slug
+	}
+	g.writeResource(l.Id, importID, []attribute{
+		{"type", l.Type},
+		{"integration_id", g.resolve(l.IntegrationId)},
+		{"settings", g.optionalMap(l.Settings)},
+	})
+}
+
+func (g *generator) writeEnvironment(env client.Environment) {
+	g.writeResource(env.Id, env.Name, []attribute{
+		{"name", env.Name},
+		{"label", optional(env.Label)},
+		{"runtime_id", g.optionalRef(env.RuntimeId)},
+		{"error_logger_id", g.optionalRef(env.ErrorLoggerId)},
+		{"log_destination_ids", g.optionalList(env.LogDestinationIds)},
+		{"integrations", g.optionalMap(env.Integrations)},
+	})
+}
+
+func (g *generator) writeFlow(f client.Flow) {
+	name := strings.SplitN(g.addresses[f.Id], ".", 2)[1]
+
+	body := g.writeResource(f.Id, f.Name, []attribute{
+		{"name", f.Name},
+		{"label", optional(f.Label)},
+		{"implementation", g.writeImpl(name, decodeImpl(f.Implementation))},
+		{"environment_id", g.resolve(f.EnvironmentId)},
+		{"vars", g.optionalMap(f.Vars)},
+	})
+
+	if len(f.Params) > 0 {
+		g.writeFlowParams(body.AppendNewBlock("params", nil).Body(), name, f.Params)
+	}
+}
+
+// writeFlowParams writes the params of a Flow that are supported by sym_flow, omitting any
+// that are set to the schema default. Params unknown to the provider are not exported.
+func (g *generator) writeFlowParams(body *hclwrite.Body, flowName string, params map[string]interface{}) {
+	paramDefaults, promptFieldParamDefaults := provider.FlowParamDefaults()
+
+	var attributes []attribute
+	for _, k := range sortedKeys(params) {
+		defaultValue, ok := paramDefaults[k]
+		if !ok || k == "prompt_fields" || isDefault(defaultValue, params[k]) {
+			continue
+		}
+		// An empty list of allowed_sources is meaningful, and must not be omitted.
+		if k != "allowed_sources" && isEmptyList(params[k]) {
+			continue
+		}
+		attributes = append(attributes, attribute{k, g.resolve(params[k])})
+	}
+	setAttributes(body, attributes)
+
+	promptFields, _ := params["prompt_fields"].([]interface{})
+	for _, p := range promptFields {
+		promptField, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		attributes := []attribute{{"name", promptField["name"]}, {"type", promptField["type"]}}
+		for _, k := range sortedKeys(promptField) {
+			defaultValue, ok := promptFieldParamDefaults[k]
+			if !ok || k == "name" || k == "type" || isDefault(defaultValue, promptField[k]) || isEmptyList(promptField[k]) {
+				continue
+			}
+
+			value := promptField[k]
+			if k == "on_change" {
+				value = g.writeImpl(fmt.Sprintf("%s_%s_on_change", flowName, terraformName(fmt.Sprint(promptField["name"]))), decodeImpl(fmt.Sprint(value)))
+			}
+			attributes = append(attributes, attribute{k, value})
+		}
+
+		body.AppendNewline()
+		setAttributes(body.AppendNewBlock("prompt_field", nil).Body(), attributes)
+	}
+}
+
+func (g *generator) writeFlowsFilter(f client.FlowsFilter) {
+	// sym_flows_filter is a singleton, so any import ID resolves to the org's FlowsFilter.
+	g.writeResource(f.Id, "flows-filter", []attribute{
+		{"implementation", g.writeImpl("flows_filter", decodeImpl(f.Implementation))},
+		{"vars", g.optionalMap(f.Vars)},
+		{"integrations", g.optionalMap(f.Integrations)},
+	})
+}
+
+// orId returns the slug if it is set, and otherwise the ID.
+func orId(slug, id string) string {
+	if slug != "" {
+		return slug
+	}
+	return id
+}
+
+// isDefault reports whether the value is nil, an empty string, or the param's default.
+func isDefault(defaultValue, value interface{}) bool {
+	if value == nil || value == "" {
+		return true
+	}
+	return defaultValue != nil && reflect.DeepEqual(defaultValue, value)
+}
+
+func isEmptyList(value interface{}) bool {
+	l, ok := value.([]interface{})
+	return ok && len(l) == 0
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,248 @@
+package export
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+)
+
+func testEntities() *entities {
+	return &entities{
+		Integrations: []client.Integration{
+			{Id: "integration-slack", Type: "slack", Name: "slack", ExternalId: "T12345"},
+			{Id: "integration-context", Type: "permission_context", Name: "runtime-context", Label: "Runtime Context", ExternalId: "123456789012", Settings: client.Settings{"region": "us-east-1"}},
+		},
+		Targets: []client.Target{
+			{Id: "target-prod", Type: "aws_sso_permission_set", Name: "prod", Label: "Prod", Settings: client.Settings{"account_id": "012345678910"}},
+		},
+		Strategies: []client.Strategy{
+			{Id: "strategy-sso", Type: "aws_sso", Name: "sso", IntegrationId: "integration-context", Targets: []client.StrategyTarget{{TargetId: "target-prod", Tags: client.Tags{"risk": "high"}}}},
+		},
+		Runtimes: []client.Runtime{
+			{Id: "runtime", Name: "runtime", ContextId: "integration-context"},
+		},
+		ErrorLoggers: []client.ErrorLogger{
+			{Id: "error-logger", IntegrationId: "integration-slack", Destination: "#sym-errors"},
+		},
+		Environments: []client.Environment{
+			{Id: "environment", Name: "prod", RuntimeId: "runtime", ErrorLoggerId: "error-logger", Integrations: map[string]string{"slack_id": "integration-slack"}},
+		},
+		Flows: []client.Flow{
+			{
+				Id:             "flow",
+				Name:           "sso-access",
+				Label:          "SSO Access",
+				Implementation: base64.StdEncoding.EncodeToString([]byte("print('impl')\n")),
+				EnvironmentId:  "environment",
+				Vars:           client.Settings{"strategy": "strategy-sso"},
+				Params: map[string]interface{}{
+					"strategy_id":     "strategy-sso",
+					"allow_revoke":    true,
+					"allowed_sources": []interface{}{},
+					"unknown_param":   "ignored",
+					"prompt_fields": []interface{}{
+						map[string]interface{}{
+							"name":      "reason",
+							"type":      "string",
+							"required":  true,
+							"label":     "Reason",
+							"on_change": base64.StdEncoding.EncodeToString([]byte("print('on_change')\n")),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_generate(t *testing.T) {
+	files := generate("test-org", testEntities())
+
+	assert.Equal(t, `terraform {
+  required_providers {
+    sym = {
+      source = "symopsio/sym"
+    }
+  }
+}
+
+provider "sym" {
+  org = "test-org"
+}
+
+resource "sym_integration" "slack" {
+  type        = "slack"
+  name        = "slack"
+  external_id = "T12345"
+}
+
+resource "sym_integration" "runtime_context" {
+  type        = "permission_context"
+  name        = "runtime-context"
+  label       = "Runtime Context"
+  external_id = "123456789012"
+  settings = {
+    region = "us-east-1"
+  }
+}
+
+resource "sym_target" "prod" {
+  type  = "aws_sso_permission_set"
+  name  = "prod"
+  label = "Prod"
+  settings = {
+    account_id = "012345678910"
+  }
+}
+
+resource "sym_strategy" "sso" {
+  type           = "aws_sso"
+  name           = "sso"
+  integration_id = sym_integration.runtime_context.id
+
+  target {
+    id = sym_target.prod.id
+    tags = {
+      risk = "high"
+    }
+  }
+}
+
+resource "sym_runtime" "runtime" {
+  name       = "runtime"
+  context_id = sym_integration.runtime_context.id
+}
+
+resource "sym_error_logger" "sym_errors" {
+  integration_id = sym_integration.slack.id
+  destination    = "#sym-errors"
+}
+
+resource "sym_environment" "prod" {
+  name            = "prod"
+  runtime_id      = sym_runtime.runtime.id
+  error_logger_id = sym_error_logger.sym_errors.id
+  integrations = {
+    slack_id = sym_integration.slack.id
+  }
+}
+
+resource "sym_flow" "sso_access" {
+  name           = "sso-access"
+  label          = "SSO Access"
+  implementation = file("${path.module}/impls/sso_access.py")
+  environment_id = sym_environment.prod.id
+  vars = {
+    strategy = sym_strategy.sso.id
+  }
+  params {
+    allowed_sources = []
+    strategy_id     = sym_strategy.sso.id
+
+    prompt_field {
+      name      = "reason"
+      type      = "string"
+      label     = "Reason"
+      on_change = file("${path.module}/impls/sso_access_reason_on_change.py")
+    }
+  }
+}
+`, string(files[resourcesFile]))
+
+	assert.Equal(t, `import {
+  to = sym_integration.slack
+  id = "slack:slack"
+}
+
+import {
+  to = sym_integration.runtime_context
+  id = "permission_context:runtime-context"
+}
+
+import {
+  to = sym_target.prod
+  id = "aws_sso_permission_set:prod"
+}
+
+import {
+  to = sym_strategy.sso
+  id = "aws_sso:sso"
+}
+
+import {
+  to = sym_runtime.runtime
+  id = "runtime"
+}
+
+import {
+  to = sym_error_logger.sym_errors
+  id = "error-logger"
+}
+
+import {
+  to = sym_environment.prod
+  id = "prod"
+}
+
+import {
+  to = sym_flow.sso_access
+  id = "sso-access"
+}
+
+`, string(files[importsFile]))
+
+	assert.Equal(t, "print('impl')\n", string(files["impls/sso_access.py"]))
+	assert.Equal(t, "print('on_change')\n", string(files["impls/sso_access_reason_on_change.py"]))
+	assert.Len(t, files, 4)
+}
+
+func Test_terraformName(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"slug", "prod-access", "prod_access"},
+		{"uppercase", "Prod_Access", "prod_access"},
+		{"special-characters", "#sym-errors", "sym_errors"},
+		{"leading-digit", "1password", "_1password"},
+		{"empty", "---", "this"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := terraformName(tt.value); got != tt.want {
+				t.Errorf("terraformName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_generator_assign(t *testing.T) {
+	g := &generator{addresses: map[string]string{}, usedNames: map[string]map[string]bool{}}
+
+	assert.Equal(t, "sym_flow.access", g.assign("1", "sym_flow", "access"))
+	assert.Equal(t, "sym_flow.access_2", g.assign("2", "sym_flow", "Access"))
+	assert.Equal(t, "sym_target.access", g.assign("3", "sym_target", "access"))
+	assert.Equal(t, reference("sym_flow.access_2.id"), g.resolve("2"))
+	assert.Equal(t, "unknown", g.resolve("unknown"))
+}
+
+func Test_generator_writeImpl(t *testing.T) {
+	g := &generator{files: map[string]string{}}
+
+	// A Flow slugged flows_filter, and a Flow whose name matches another Flow's on_change file.
+	assert.Equal(t, fileCall("impls/flows_filter.py"), g.writeImpl("flows_filter", "flow"))
+	assert.Equal(t, fileCall("impls/flows_filter_2.py"), g.writeImpl("flows_filter", "filter"))
+	assert.Equal(t, fileCall("impls/a_b_on_change.py"), g.writeImpl("a_b_on_change", ""))
+	assert.Equal(t, fileCall("impls/a_b_on_change_2.py"), g.writeImpl("a_b_on_change", "on_change"))
+
+	assert.Equal(t, map[string]string{
+		"impls/flows_filter.py":    "flow",
+		"impls/flows_filter_2.py":  "filter",
+		"impls/a_b_on_change.py":   "",
+		"impls/a_b_on_change_2.py": "on_change",
+	}, g.files)
+}
@@ -0,0 +1,119 @@
+package export
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// reference is a reference to an attribute of another resource, e.g. "sym_environment.prod.id".
+type reference string
+
+// fileCall is a call to Terraform's file() function reading the given path relative to the module.
+type fileCall string
+
+// attribute is a single HCL attribute. Attributes with a nil value are not written.
+type attribute struct {
+	name  string
+	value interface{}
+}
+
+// setAttributes writes the given attributes to the body in order, skipping nil values.
+func setAttributes(body *hclwrite.Body, attributes []attribute) {
+	for _, a := range attributes {
+		if a.value == nil {
+			continue
+		}
+		body.SetAttributeRaw(a.name, tokensFor(a.value))
+	}
+}
+
+// tokensFor returns the HCL tokens for a value built from strings, bools, numbers,
+// references, file() calls, and slices or maps of those.
+func tokensFor(value interface{}) hclwrite.Tokens {
+	switch v := value.(type) {
+	case reference:
+		return hclwrite.TokensForTraversal(traversalFor(string(v)))
+	case fileCall:
+		return tokensForFileCall(string(v))
+	case string:
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v))
+	case []interface{}:
+		return tokensForTuple(v)
+	case map[string]interface{}:
+		return tokensForObject(v)
+	default:
+		return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType))
+	}
+}
+
+func traversalFor(address string) hcl.Traversal {
+	parts := strings.Split(address, ".")
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: parts[0]}}
+	for _, part := range parts[1:] {
+		traversal = append(traversal, hcl.TraverseAttr{Name: part})
+	}
+	return traversal
+}
+
+// tokensForFileCall returns the tokens for `file("${path.module}/<path>")`.
+func tokensForFileCall(path string) hclwrite.Tokens {
+	return hclwrite.Tokens{
+		{Type: hclsyntax.TokenIdent, Bytes: []byte("file")},
+		{Type: hclsyntax.TokenOParen, Bytes: []byte("(")},
+		{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)},
+		{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte("path")},
+		{Type: hclsyntax.TokenDot, Bytes: []byte(".")},
+		{Type: hclsyntax.TokenIdent, Bytes: []byte("module")},
+		{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")},
+		{Type: hclsyntax.TokenQuotedLit, Bytes: []byte("/" + path)},
+		{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)},
+		{Type: hclsyntax.TokenCParen, Bytes: []byte(")")},
+	}
+}
+
+func tokensForTuple(values []interface{}) hclwrite.Tokens {
+	tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")}}
+	for i, v := range values {
+		if i > 0 {
+			tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")})
+		}
+		tokens = append(tokens, tokensFor(v)...)
+	}
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+}
+
+func tokensForObject(values map[string]interface{}) hclwrite.Tokens {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOBrace, Bytes: []byte("{")}}
+	if len(keys) > 0 {
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+	}
+	for _, k := range keys {
+		if hclsyntax.ValidIdentifier(k) {
+			tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte(k)})
+		} else {
+			tokens = append(tokens, hclwrite.TokensForValue(cty.StringVal(k))...)
+		}
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenEqual, Bytes: []byte("=")})
+		tokens = append(tokens, tokensFor(values[k])...)
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+	}
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCBrace, Bytes: []byte("}")})
+}
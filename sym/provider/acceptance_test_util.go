@@ -41,24 +41,31 @@ type resourceTemplate interface {
 }
 
 type providerResource struct {
-	org string
+	org                 string
+	hashImplementations bool
 }
 
 func (r providerResource) String() string {
+	var hashImplementations string
+	if r.hashImplementations {
+		hashImplementations = "\thash_implementations = true\n"
+	}
+
 	return fmt.Sprintf(`
 provider "sym" {
 	org = %q
-}
-`, r.org)
+%s}
+`, r.org, hashImplementations)
 }
 
 type integrationResource struct {
-	terraformName string
-	type_         string
-	name          string
-	label         string
-	externalId    string
-	settings      map[string]string
+	terraformName     string
+	type_             string
+	name              string
+	label             string
+	externalId        string
+	settings          map[string]string
+	sensitiveSettings map[string]string
 }
 
 func (r integrationResource) String() string {
@@ -95,6 +102,18 @@ resource "sym_integration" %[1]q {
 		}
 		sb.WriteString("	}\n")
 	}
+	if len(r.sensitiveSettings) > 0 {
+		keys := make([]string, 0, len(r.sensitiveSettings))
+		for k := range r.sensitiveSettings {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		sb.WriteString("	sensitive_settings = {\n")
+		for _, k := range keys {
+			sb.WriteString(fmt.Sprintf("		%s = %q\n", k, r.sensitiveSettings[k]))
+		}
+		sb.WriteString("	}\n")
+	}
 	sb.WriteString("}\n")
 
 	return sb.String()
@@ -124,6 +143,21 @@ resource "sym_runtime" %[1]q {
 	return sb.String()
 }
 
+type botResource struct {
+	terraformName string
+	name          string
+	label         string
+}
+
+func (r botResource) String() string {
+	return fmt.Sprintf(`
+resource "sym_bot" %[1]q {
+	name = %[2]q
+	label = %[3]q
+}
+`, r.terraformName, r.name, r.label)
+}
+
 type logDestinationResource struct {
 	terraformName string
 	type_         string
@@ -144,11 +178,13 @@ resource "sym_log_destination" %q {
 }
 
 type secretResource struct {
-	terraformName string
-	label         string
-	path          string
-	sourceId      string
-	settings      map[string]string
+	terraformName  string
+	label          string
+	path           string
+	sourceId       string
+	settings       map[string]string
+	valueWO        string
+	valueWOVersion int
 }
 
 func (r secretResource) String() string {
@@ -176,6 +212,11 @@ resource "sym_secret" %[1]q {
 		sb.WriteString("	}\n")
 	}
 
+	if r.valueWO != "" {
+		sb.WriteString(fmt.Sprintf("	value_wo = %q\n", r.valueWO))
+		sb.WriteString(fmt.Sprintf("	value_wo_version = %d\n", r.valueWOVersion))
+	}
+
 	sb.WriteString("}\n")
 
 	return sb.String()
@@ -246,12 +287,20 @@ type strategyResource struct {
 	name           string
 	label          string
 	integrationId  string
-	targetIds      []string
 	implementation string
 
+	// targetIds sets the deprecated `targets` attribute, and targets sets `target` blocks.
+	targetIds []string
+	targets   []strategyTarget
+
 	settings map[string]string
 }
 
+type strategyTarget struct {
+	id   string
+	tags map[string]string
+}
+
 func (r strategyResource) String() string {
 	var sb strings.Builder
 
@@ -261,8 +310,28 @@ resource "sym_strategy" %[1]q {
 	name = %[3]q
 	label = %[4]q
 	integration_id = %[5]s
-	targets = [ %[6]s ]
-`, r.terraformName, r.type_, r.name, r.label, r.integrationId, strings.Join(r.targetIds[:], ", ")))
+`, r.terraformName, r.type_, r.name, r.label, r.integrationId))
+
+	if len(r.targetIds) > 0 {
+		sb.WriteString(fmt.Sprintf("\ttargets = [ %s ]\n", strings.Join(r.targetIds, ", ")))
+	}
+
+	for _, target := range r.targets {
+		sb.WriteString(fmt.Sprintf("\ttarget {\n\t\tid = %s\n", target.id))
+		if len(target.tags) > 0 {
+			keys := make([]string, 0, len(target.tags))
+			for k := range target.tags {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			sb.WriteString("\t\ttags = {\n")
+			for _, k := range keys {
+				sb.WriteString(fmt.Sprintf("\t\t\t%s = %q\n", k, target.tags[k]))
+			}
+			sb.WriteString("\t\t}\n")
+		}
+		sb.WriteString("\t}\n")
+	}
 
 	if r.implementation != "" {
 		sb.WriteString(fmt.Sprintf("\timplementation = %q\n", r.implementation))
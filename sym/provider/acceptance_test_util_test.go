@@ -23,6 +23,19 @@ func Test_providerResource_String(t *testing.T) {
 provider "sym" {
 	org = "test-org"
 }
+`,
+		},
+		{
+			"provider-with-hashed-implementations",
+			providerResource{
+				org:                 "test-org",
+				hashImplementations: true,
+			},
+			`
+provider "sym" {
+	org = "test-org"
+	hash_implementations = true
+}
 `,
 		},
 	}
@@ -110,6 +123,36 @@ resource "sym_integration" "test_custom" {
 		secret_ids_json = jsonencode(["111-11-1111", "55-5555"])
 	}
 }
+`,
+		},
+		{
+			"sensitive_settings",
+			integrationResource{
+				terraformName: "test_context",
+				type_:         "permission_context",
+				name:          "runtime-test-context",
+				externalId:    "123456789012",
+				settings: map[string]string{
+					"cloud": "aws",
+				},
+				sensitiveSettings: map[string]string{
+					"role_arn":    "arn:aws:iam::123456789012:role/sym/RuntimeConnectorRole",
+					"external_id": "1478F2AD-6091-41E6-B3D2-766CA2F173CB",
+				},
+			},
+			`
+resource "sym_integration" "test_context" {
+	type = "permission_context"
+	name = "runtime-test-context"
+	external_id = "123456789012"
+	settings = {
+		cloud = "aws"
+	}
+	sensitive_settings = {
+		external_id = "1478F2AD-6091-41E6-B3D2-766CA2F173CB"
+		role_arn = "arn:aws:iam::123456789012:role/sym/RuntimeConnectorRole"
+	}
+}
 `,
 		},
 	}
@@ -385,6 +428,38 @@ resource "sym_strategy" "custom" {
 	targets = [ "888-7777", "111-222" ]
 	implementation = "path/to/thing.py"
 }
+`,
+		},
+		{
+			"target-blocks",
+			strategyResource{
+				terraformName: "tagged",
+				name:          "test-tagged-strategy",
+				type_:         "aws_sso",
+				label:         "Tagged Strategy",
+				integrationId: "sym_integration.sso.id",
+				targets: []strategyTarget{
+					{id: "sym_target.prod.id", tags: map[string]string{"risk": "high", "approvers": "sre"}},
+					{id: "sym_target.staging.id"},
+				},
+			},
+			`
+resource "sym_strategy" "tagged" {
+	type = "aws_sso"
+	name = "test-tagged-strategy"
+	label = "Tagged Strategy"
+	integration_id = sym_integration.sso.id
+	target {
+		id = sym_target.prod.id
+		tags = {
+			approvers = "sre"
+			risk = "high"
+		}
+	}
+	target {
+		id = sym_target.staging.id
+	}
+}
 `,
 		},
 	}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+func Bot() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The `sym_bot` resource allows you to create a Sym bot user, which authenticates with tokens instead of logging in. Tokens can be issued with the `sym_token` ephemeral resource. For more details, see [Using Bot Tokens](https://docs.symops.com/docs/using-bot-tokens).",
+		CreateContext: createBot,
+		ReadContext:   readBot,
+		UpdateContext: updateBot,
+		DeleteContext: deleteBot,
+		CustomizeDiff: customdiff.All(
+			customizeDiffFullName,
+			validateOnPlan("Bot", botPatchFields, validateBotOnPlan),
+		),
+		Importer: &schema.ResourceImporter{
+			StateContext: getImporter("bot", botImportCandidates),
+		},
+		Schema: map[string]*schema.Schema{
+			"name":                utils.RequiredCaseInsensitiveString("The username of this Sym Bot."),
+			"full_name":           fullNameSchema(),
+			"label":               utils.Optional(schema.TypeString, "An optional label for the Bot."),
+			"deletion_protection": utils.DeletionProtection(),
+		},
+	}
+}
+
+// botImportCandidates lists every Bot that may be imported as a sym_bot.
+var botImportCandidates = importCandidatesOf(
+	func(c *client.ApiClient) ([]client.Bot, error) { return c.Bot.List(nil) },
+	func(bot client.Bot) importCandidate { return importCandidate{Id: bot.Id, Slug: bot.Name} },
+)
+
+// buildBot returns the Bot configured by the resource.
+func buildBot(data resourceGetter, m *providerMeta) client.Bot {
+	return client.Bot{
+		Name:  m.Naming.fullName(data.Get("name").(string)),
+		Label: m.Naming.fullLabel(data.Get("label").(string)),
+	}
+}
+
+func validateBotOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	bot := buildBot(d, m)
+	bot.Id = d.Id()
+	return m.Client.Bot.Validate(bot)
+}
+
+func createBot(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	c := m.Client
+	bot := buildBot(data, m)
+
+	id, err := c.Bot.Create(bot)
+	if err != nil {
+		return utils.DiagsFromError(err, "Unable to create Bot")
+	}
+
+	data.SetId(id)
+	return nil
+}
+
+func readBot(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var (
+		diags diag.Diagnostics
+		bot   *client.Bot
+		err   error
+	)
+	m := meta.(*providerMeta)
+	c := m.Client
+	id := data.Id()
+
+	bot, err = c.Bot.Read(id)
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			log.Println(notFoundWarning("Bot", id))
+			data.SetId("")
+			return nil
+		}
+		diags = append(diags, utils.DiagFromError(err, "Unable to read Bot"))
+		return diags
+	}
+
+	diags = utils.DiagsCheckError(diags, data.Set("name", m.Naming.readName(data.Get("name").(string), bot.Name)), "Unable to read Bot name")
+	diags = utils.DiagsCheckError(diags, data.Set("full_name", bot.Name), "Unable to read Bot full_name")
+	diags = utils.DiagsCheckError(diags, data.Set("label", m.Naming.readLabel(data.Get("label").(string), bot.Label)), "Unable to read Bot label")
+
+	diags = append(diags, setMetadata(data, "Bot", bot.Name, bot.Metadata)...)
+
+	return diags
+}
+
+// botPatchFields are the Bot fields sent when their attributes change.
+var botPatchFields = patchFields{
+	"username": {"name", "full_name"},
+	"label":    {"label"},
+}
+
+func updateBot(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m := meta.(*providerMeta)
+	c := m.Client
+
+	bot := buildBot(data, m)
+	bot.Id = data.Id()
+
+	patch, err := buildPatch(data, bot, botPatchFields)
+	if err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to update Bot"))
+		return diags
+	}
+	if len(patch) == 0 {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).Bot.Patch(data.Id(), patch); err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to update Bot"))
+	}
+
+	return diags
+}
+
+func deleteBot(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	c := meta.(*providerMeta).Client
+	id := data.Id()
+
+	if diags = checkDeletion(data, c, "Bot", nil); diags.HasError() {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).Bot.Delete(id); err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to delete Bot"))
+	}
+
+	return diags
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSymBot_basic(t *testing.T) {
+	botData := BuildTestData("basic-bot")
+	createBotConfig := botConfig(botData, "Test Bot")
+	updateBotConfig := botConfig(botData, "Updated Test Bot")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: createBotConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sym_bot.this", "name", botData.ResourceName),
+					resource.TestCheckResourceAttr("sym_bot.this", "label", "Test Bot"),
+				),
+			},
+			{
+				Config: updateBotConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sym_bot.this", "name", botData.ResourceName),
+					resource.TestCheckResourceAttr("sym_bot.this", "label", "Updated Test Bot"),
+				),
+			},
+		},
+	})
+}
+
+func botConfig(data TestData, label string) string {
+	return makeTerraformConfig(
+		providerResource{org: data.OrgSlug},
+		botResource{terraformName: "this", name: data.ResourceName, label: label},
+	)
+}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+// dependent is an existing entity which references an entity that is about to be deleted.
+type dependent struct {
+	Resource string
+	Id       string
+	Slug     string
+}
+
+func (d dependent) String() string {
+	return fmt.Sprintf("%s %q (%s)", d.Resource, d.Slug, d.Id)
+}
+
+// dependentsFunc lists every entity which references the entity with the given ID.
+type dependentsFunc func(c *client.ApiClient, id string) ([]dependent, error)
+
+// checkDeletion returns an error diagnostic if the resource may not be deleted, either because its
+// `deletion_protection` attribute is set, or because other Sym entities still reference it.
+// findDependents may be nil for resources that cannot be referenced by other entities.
+//
+// The `resource` provided will be used for error message details.
+func checkDeletion(data *schema.ResourceData, c *client.ApiClient, resource string, findDependents dependentsFunc) diag.Diagnostics {
+	summary := fmt.Sprintf("Unable to delete %s", resource)
+
+	if data.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{deletionProtectedError(resource)}
+	}
+
+	if findDependents == nil {
+		return nil
+	}
+
+	dependents, err := findDependents(c, data.Id())
+	if err != nil {
+		return utils.DiagsFromError(err, fmt.Sprintf("Unable to check for dependents of %s", resource))
+	}
+	if len(dependents) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(dependents))
+	for i, d := range dependents {
+		lines[i] = "  - " + d.String()
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail: fmt.Sprintf(
+			"The %s is still referenced by the following, which must be updated or deleted first:\n%s",
+			resource,
+			strings.Join(lines, "\n"),
+		),
+	}}
+}
+
+// deletionProtectedError is the error returned when deleting a resource with `deletion_protection` enabled.
+func deletionProtectedError(resource string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Unable to delete %s", resource),
+		Detail:   fmt.Sprintf("The %s has deletion_protection enabled. Set deletion_protection to false and apply before deleting it.", resource),
+	}
+}
+
+func integrationDependents(c *client.ApiClient, id string) ([]dependent, error) {
+	var dependents []dependent
+
+	// The entities are also filtered locally, since not every reference can be filtered by the API.
+	byIntegration := client.NewQuery().IntegrationId(id)
+
+	strategies, err := c.Strategy.List(byIntegration)
+	if err != nil {
+		return nil, err
+	}
+	for _, strategy := range strategies {
+		if strategy.IntegrationId == id {
+			dependents = append(dependents, dependent{"sym_strategy", strategy.Id, strategy.Name})
+		}
+	}
+
+	runtimes, err := c.Runtime.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, runtime := range runtimes {
+		if runtime.ContextId == id {
+			dependents = append(dependents, dependent{"sym_runtime", runtime.Id, runtime.Name})
+		}
+	}
+
+	errorLoggers, err := c.ErrorLogger.List(byIntegration)
+	if err != nil {
+		return nil, err
+	}
+	for _, errorLogger := range errorLoggers {
+		if errorLogger.IntegrationId == id {
+			dependents = append(dependents, dependent{"sym_error_logger", errorLogger.Id, errorLogger.Destination})
+		}
+	}
+
+	logDestinations, err := c.LogDestination.List(byIntegration)
+	if err != nil {
+		return nil, err
+	}
+	for _, logDestination := range logDestinations {
+		if logDestination.IntegrationId == id {
+			dependents = append(dependents, dependent{"sym_log_destination", logDestination.Id, logDestination.Type})
+		}
+	}
+
+	environments, err := c.Environment.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, environment := range environments {
+		for _, integrationId := range environment.Integrations {
+			if integrationId == id {
+				dependents = append(dependents, dependent{"sym_environment", environment.Id, environment.Name})
+				break
+			}
+		}
+	}
+
+	return dependents, nil
+}
+
+func targetDependents(c *client.ApiClient, id string) ([]dependent, error) {
+	strategies, err := c.Strategy.List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []dependent
+	for _, strategy := range strategies {
+		if utils.ContainsString(strategy.TargetIds(), id) {
+			dependents = append(dependents, dependent{"sym_strategy", strategy.Id, strategy.Name})
+		}
+	}
+	return dependents, nil
+}
+
+func strategyDependents(c *client.ApiClient, id string) ([]dependent, error) {
+	flows, err := c.Flow.List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []dependent
+	for _, flow := range flows {
+		if flow.Params["strategy_id"] == id {
+			dependents = append(dependents, dependent{"sym_flow", flow.Id, flow.Name})
+		}
+	}
+	return dependents, nil
+}
+
+func environmentDependents(c *client.ApiClient, id string) ([]dependent, error) {
+	flows, err := c.Flow.List(client.NewQuery().EnvironmentId(id))
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []dependent
+	for _, flow := range flows {
+		if flow.EnvironmentId == id {
+			dependents = append(dependents, dependent{"sym_flow", flow.Id, flow.Name})
+		}
+	}
+	return dependents, nil
+}
+
+// environmentDependentsWhere returns a dependentsFunc listing the Environments for which
+// references returns true.
+func environmentDependentsWhere(references func(environment client.Environment, id string) bool) dependentsFunc {
+	return func(c *client.ApiClient, id string) ([]dependent, error) {
+		environments, err := c.Environment.List(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var dependents []dependent
+		for _, environment := range environments {
+			if references(environment, id) {
+				dependents = append(dependents, dependent{"sym_environment", environment.Id, environment.Name})
+			}
+		}
+		return dependents, nil
+	}
+}
+
+var runtimeDependents = environmentDependentsWhere(func(environment client.Environment, id string) bool {
+	return environment.RuntimeId == id
+})
+
+var errorLoggerDependents = environmentDependentsWhere(func(environment client.Environment, id string) bool {
+	return environment.ErrorLoggerId == id
+})
+
+var logDestinationDependents = environmentDependentsWhere(func(environment client.Environment, id string) bool {
+	return utils.ContainsString(environment.LogDestinationIds, id)
+})
+
+func secretsDependents(c *client.ApiClient, id string) ([]dependent, error) {
+	secrets, err := c.Secret.List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []dependent
+	for _, secret := range secrets {
+		if secret.SourceId == id {
+			dependents = append(dependents, dependent{"sym_secret", secret.Id, secret.Path})
+		}
+	}
+	return dependents, nil
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+func Test_checkDeletion(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"deletion_protection": utils.DeletionProtection(),
+	}
+
+	noDependents := func(_ *client.ApiClient, _ string) ([]dependent, error) {
+		return nil, nil
+	}
+	someDependents := func(_ *client.ApiClient, id string) ([]dependent, error) {
+		assert.Equal(t, "environment-id", id)
+		return []dependent{
+			{"sym_flow", "flow-1", "sso-access"},
+			{"sym_flow", "flow-2", "okta-access"},
+		}, nil
+	}
+	failedDependents := func(_ *client.ApiClient, _ string) ([]dependent, error) {
+		return nil, errors.New("boom")
+	}
+
+	tests := []struct {
+		name               string
+		deletionProtection bool
+		findDependents     dependentsFunc
+		wantSummary        string
+		wantDetail         string
+	}{
+		{"no-dependents-func", false, nil, "", ""},
+		{"no-dependents", false, noDependents, "", ""},
+		{
+			"deletion-protection",
+			true,
+			noDependents,
+			"Unable to delete Environment",
+			"The Environment has deletion_protection enabled. Set deletion_protection to false and apply before deleting it.",
+		},
+		{
+			"has-dependents",
+			false,
+			someDependents,
+			"Unable to delete Environment",
+			"The Environment is still referenced by the following, which must be updated or deleted first:\n" +
+				"  - sym_flow \"sso-access\" (flow-1)\n" +
+				"  - sym_flow \"okta-access\" (flow-2)",
+		},
+		{"dependents-lookup-failed", false, failedDependents, "Unable to check for dependents of Environment", "boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{"deletion_protection": tt.deletionProtection})
+			data.SetId("environment-id")
+
+			diags := checkDeletion(data, nil, "Environment", tt.findDependents)
+			if tt.wantSummary == "" {
+				assert.False(t, diags.HasError())
+				return
+			}
+
+			assert.Len(t, diags, 1)
+			assert.Equal(t, tt.wantSummary, diags[0].Summary)
+			assert.Equal(t, tt.wantDetail, diags[0].Detail)
+		})
+	}
+}
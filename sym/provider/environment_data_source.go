@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	"github.com/symopsio/terraform-provider-sym/sym/client"
 	"github.com/symopsio/terraform-provider-sym/sym/utils"
 )
 
@@ -27,7 +26,7 @@ func DataSourceEnvironment() *schema.Resource {
 
 func dataSourceEnvironmentRead(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	name := data.Get("name").(string)
 
 	environment, err := c.Environment.Find(name)
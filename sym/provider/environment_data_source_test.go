@@ -11,8 +11,8 @@ func TestAccSymEnvironmentData_basic(t *testing.T) {
 	data := BuildTestData("basic-data-environment")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: environmentDataConfig(data),
@@ -4,8 +4,8 @@ import (
 	"context"
 	"log"
 
-	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/symopsio/terraform-provider-sym/sym/client"
@@ -23,39 +23,76 @@ func Environment() *schema.Resource {
 		ReadContext:   readEnvironment,
 		UpdateContext: updateEnvironment,
 		DeleteContext: deleteEnvironment,
+		CustomizeDiff: customdiff.All(
+			customizeDiffFullName,
+			validateOnPlan("Environment", environmentPatchFields, validateEnvironmentOnPlan),
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: getSlugImporter("environment"),
+			StateContext: getImporter("environment", environmentImportCandidates),
 		},
-		Schema: map[string]*schema.Schema{
-			"name":                utils.RequiredCaseInsensitiveString("A unique identifier for the Environment"),
-			"label":               utils.Optional(schema.TypeString, "An optional label for the Environment"),
-			"runtime_id":          utils.Optional(schema.TypeString, "The ID of the Runtime associated with this Environment"),
-			"integrations":        utils.SettingsMap("A map of Integrations available to this Environment"),
-			"error_logger_id":     utils.Optional(schema.TypeString, "The ID of the Error Logger"),
-			"log_destination_ids": utils.StringList(false, "IDs for each Log Destination to funnel logs to"),
+		Schema:        environmentSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    withListsOf(environmentSchema(), "log_destination_ids").CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeListsToSets("log_destination_ids"),
+			},
 		},
 	}
 }
 
+func environmentSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name":                utils.RequiredCaseInsensitiveString("A unique identifier for the Environment"),
+		"full_name":           fullNameSchema(),
+		"label":               utils.Optional(schema.TypeString, "An optional label for the Environment"),
+		"runtime_id":          utils.Optional(schema.TypeString, "The ID of the Runtime associated with this Environment"),
+		"integrations":        utils.SettingsMap("A map of Integrations available to this Environment"),
+		"error_logger_id":     utils.Optional(schema.TypeString, "The ID of the Error Logger"),
+		"log_destination_ids": utils.StringSet(false, "IDs for each Log Destination to funnel logs to"),
+		"deletion_protection": utils.DeletionProtection(),
+	}
+}
+
 // CRUD Functions ///////////////////////////////
 
-// Create an environment using the HTTP client
-func createEnvironment(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+// environmentImportCandidates lists every Environment that may be imported as a sym_environment.
+var environmentImportCandidates = importCandidatesOf(
+	func(c *client.ApiClient) ([]client.Environment, error) { return c.Environment.List(nil) },
+	func(environment client.Environment) importCandidate {
+		return importCandidate{Id: environment.Id, Slug: environment.Name}
+	},
+)
 
+// buildEnvironment returns the Environment configured by the resource.
+func buildEnvironment(data resourceGetter, m *providerMeta) client.Environment {
 	environment := client.Environment{
-		Name:          data.Get("name").(string),
-		Label:         data.Get("label").(string),
+		Name:          m.Naming.fullName(data.Get("name").(string)),
+		Label:         m.Naming.fullLabel(data.Get("label").(string)),
 		RuntimeId:     data.Get("runtime_id").(string),
 		Integrations:  getSettingsMap(data, "integrations"),
 		ErrorLoggerId: data.Get("error_logger_id").(string),
 	}
 
-	logDestinationIds := data.Get("log_destination_ids").([]interface{})
-	for i := range logDestinationIds {
-		environment.LogDestinationIds = append(environment.LogDestinationIds, logDestinationIds[i].(string))
-	}
+	environment.LogDestinationIds = utils.SetStrings(data.Get("log_destination_ids").(*schema.Set))
+
+	return environment
+}
+
+func validateEnvironmentOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	environment := buildEnvironment(d, m)
+	environment.Id = d.Id()
+	return m.Client.Environment.Validate(environment)
+}
+
+// Create an environment using the HTTP client
+func createEnvironment(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m := meta.(*providerMeta)
+	c := m.Client
+
+	environment := buildEnvironment(data, m)
 
 	if id, err := c.Environment.Create(environment); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to create Environment"))
@@ -73,20 +110,13 @@ func readEnvironment(_ context.Context, data *schema.ResourceData, meta interfac
 		environment *client.Environment
 		err         error
 	)
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 	id := data.Id()
 
-	if _, parseErr := uuid.ParseUUID(id); parseErr == nil {
-		// If the ID is a UUID, look up the Environment directly.
-		environment, err = c.Environment.Read(id)
-	} else {
-		// Otherwise, we are probably in the context of a `terraform import` and should attempt
-		// to look up the Environment by slug.
-		environment, err = c.Environment.Find(id)
-	}
-
+	environment, err = c.Environment.Read(id)
 	if err != nil {
-		if isNotFoundError(err) {
+		if utils.IsNotFoundError(err) {
 			log.Println(notFoundWarning("Environment", id))
 			data.SetId("")
 			return nil
@@ -95,41 +125,48 @@ func readEnvironment(_ context.Context, data *schema.ResourceData, meta interfac
 		return diags
 	}
 
-	// In the case of a normal read, ID will already be set and this is redundant.
-	// In the case of a `terraform import`, we need to set ID since it was previously TYPE:SLUG.
-	// This must happen below the error checking in case the lookup failed.
-	data.SetId(environment.Id)
-
-	diags = utils.DiagsCheckError(diags, data.Set("name", environment.Name), "Unable to read Environment name")
-	diags = utils.DiagsCheckError(diags, data.Set("label", environment.Label), "Unable to read Environment label")
+	diags = utils.DiagsCheckError(diags, data.Set("name", m.Naming.readName(data.Get("name").(string), environment.Name)), "Unable to read Environment name")
+	diags = utils.DiagsCheckError(diags, data.Set("full_name", environment.Name), "Unable to read Environment full_name")
+	diags = utils.DiagsCheckError(diags, data.Set("label", m.Naming.readLabel(data.Get("label").(string), environment.Label)), "Unable to read Environment label")
 	diags = utils.DiagsCheckError(diags, data.Set("runtime_id", environment.RuntimeId), "Unable to read RuntimeId")
 	diags = utils.DiagsCheckError(diags, data.Set("integrations", environment.Integrations), "Unable to read Environment integrations")
 	diags = utils.DiagsCheckError(diags, data.Set("error_logger_id", environment.ErrorLoggerId), "Unable to read ErrorLoggerId")
 	diags = utils.DiagsCheckError(diags, data.Set("log_destination_ids", environment.LogDestinationIds), "Unable to read Environment log destination ids")
 
+	diags = append(diags, setMetadata(data, "Environment", environment.Name, environment.Metadata)...)
+
 	return diags
 }
 
+// environmentPatchFields are the Environment fields sent when their attributes change.
+var environmentPatchFields = patchFields{
+	"slug":                {"name", "full_name"},
+	"label":               {"label"},
+	"runtime_id":          {"runtime_id"},
+	"integrations":        {"integrations"},
+	"error_logger_id":     {"error_logger_id"},
+	"log_destination_ids": {"log_destination_ids"},
+}
+
 // Update an existing environment using the HTTP client
 func updateEnvironment(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 
-	environment := client.Environment{
-		Id:            data.Id(),
-		Name:          data.Get("name").(string),
-		Label:         data.Get("label").(string),
-		RuntimeId:     data.Get("runtime_id").(string),
-		Integrations:  getSettingsMap(data, "integrations"),
-		ErrorLoggerId: data.Get("error_logger_id").(string),
-	}
+	environment := buildEnvironment(data, m)
+	environment.Id = data.Id()
 
-	logDestinationIds := data.Get("log_destination_ids").([]interface{})
-	for i := range logDestinationIds {
-		environment.LogDestinationIds = append(environment.LogDestinationIds, logDestinationIds[i].(string))
+	patch, err := buildPatch(data, environment, environmentPatchFields)
+	if err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to update Environment"))
+		return diags
+	}
+	if len(patch) == 0 {
+		return diags
 	}
 
-	if _, err := c.Environment.Update(environment); err != nil {
+	if _, err := ifMatch(c, data).Environment.Patch(data.Id(), patch); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to update Environment"))
 	}
 
@@ -139,10 +176,14 @@ func updateEnvironment(_ context.Context, data *schema.ResourceData, meta interf
 // Delete an environment using the HTTP client
 func deleteEnvironment(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	if _, err := c.Environment.Delete(id); err != nil {
+	if diags = checkDeletion(data, c, "Environment", environmentDependents); diags.HasError() {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).Environment.Delete(id); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to delete Environment"))
 	}
 
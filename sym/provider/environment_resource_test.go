@@ -13,8 +13,8 @@ func TestAccSymEnvironment_basic(t *testing.T) {
 	postSlack := slackIntegration(postData, "new_slack", "T0011")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: environmentConfig(preData, &preSlack, "sym_integration.slack.id"),
@@ -22,7 +22,7 @@ func TestAccSymEnvironment_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_environment.this", "name", preData.ResourceName),
 					resource.TestCheckResourceAttrPair("sym_environment.this", "runtime_id", "sym_runtime.this", "id"),
 					resource.TestCheckResourceAttr("sym_environment.this", "label", "Sandbox"),
-					resource.TestCheckResourceAttrPair("sym_environment.this", "log_destination_ids.0", "sym_log_destination.data_stream", "id"),
+					resource.TestCheckTypeSetElemAttrPair("sym_environment.this", "log_destination_ids.*", "sym_log_destination.data_stream", "id"),
 					resource.TestCheckResourceAttrPair("sym_environment.this", "integrations.slack_id", "sym_integration.slack", "id"),
 				),
 			},
@@ -32,7 +32,7 @@ func TestAccSymEnvironment_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_environment.this", "name", postData.ResourceName),
 					resource.TestCheckResourceAttrPair("sym_environment.this", "runtime_id", "sym_runtime.this", "id"),
 					resource.TestCheckResourceAttr("sym_environment.this", "label", "Sandbox"),
-					resource.TestCheckResourceAttrPair("sym_environment.this", "log_destination_ids.0", "sym_log_destination.data_stream", "id"),
+					resource.TestCheckTypeSetElemAttrPair("sym_environment.this", "log_destination_ids.*", "sym_log_destination.data_stream", "id"),
 					resource.TestCheckResourceAttrPair("sym_environment.this", "integrations.slack_id", "sym_integration.new_slack", "id"),
 				),
 			},
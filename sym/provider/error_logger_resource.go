@@ -4,7 +4,6 @@ import (
 	"context"
 	"log"
 
-	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -19,23 +18,44 @@ func ErrorLogger() *schema.Resource {
 		ReadContext:   readErrorLogger,
 		UpdateContext: updateErrorLogger,
 		DeleteContext: deleteErrorLogger,
+		CustomizeDiff: validateOnPlan("ErrorLogger", errorLoggerPatchFields, validateErrorLoggerOnPlan),
 		Importer: &schema.ResourceImporter{
-			StateContext: getSlugImporter("error_logger"),
+			StateContext: getImporter("error_logger", errorLoggerImportCandidates),
 		},
 		Schema: map[string]*schema.Schema{
-			"integration_id": utils.Required(schema.TypeString, "The ID for the Slack Integration associated with this error logger."),
-			"destination":    utils.Required(schema.TypeString, "The destination channel to send error messages to."),
+			"integration_id":      utils.Required(schema.TypeString, "The ID for the Slack Integration associated with this error logger."),
+			"destination":         utils.Required(schema.TypeString, "The destination channel to send error messages to."),
+			"deletion_protection": utils.DeletionProtection(),
 		},
 	}
 }
 
-func createErrorLogger(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client.ApiClient)
+// errorLoggerImportCandidates lists every ErrorLogger that may be imported as a sym_error_logger.
+var errorLoggerImportCandidates = importCandidatesOf(
+	func(c *client.ApiClient) ([]client.ErrorLogger, error) { return c.ErrorLogger.List(nil) },
+	func(errorLogger client.ErrorLogger) importCandidate {
+		return importCandidate{Id: errorLogger.Id, Slug: errorLogger.Slug}
+	},
+)
 
-	errorLogger := client.ErrorLogger{
+// buildErrorLogger returns the ErrorLogger configured by the resource.
+func buildErrorLogger(data resourceGetter) client.ErrorLogger {
+	return client.ErrorLogger{
 		IntegrationId: data.Get("integration_id").(string),
 		Destination:   data.Get("destination").(string),
 	}
+}
+
+func validateErrorLoggerOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	errorLogger := buildErrorLogger(d)
+	errorLogger.Id = d.Id()
+	return m.Client.ErrorLogger.Validate(errorLogger)
+}
+
+func createErrorLogger(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).Client
+
+	errorLogger := buildErrorLogger(data)
 
 	id, err := c.ErrorLogger.Create(errorLogger)
 	if err != nil {
@@ -52,20 +72,12 @@ func readErrorLogger(_ context.Context, data *schema.ResourceData, meta interfac
 		errorLogger *client.ErrorLogger
 		err         error
 	)
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	if _, parseErr := uuid.ParseUUID(id); parseErr == nil {
-		// If the ID is a UUID, look up the ErrorLogger directly.
-		errorLogger, err = c.ErrorLogger.Read(id)
-	} else {
-		// Otherwise, we are probably in the context of a `terraform import` and should attempt
-		// to look up the ErrorLogger by slug.
-		errorLogger, err = c.ErrorLogger.Find(id)
-	}
-
+	errorLogger, err = c.ErrorLogger.Read(id)
 	if err != nil {
-		if isNotFoundError(err) {
+		if utils.IsNotFoundError(err) {
 			log.Println(notFoundWarning("ErrorLogger", id))
 			data.SetId("")
 			return nil
@@ -74,27 +86,36 @@ func readErrorLogger(_ context.Context, data *schema.ResourceData, meta interfac
 		return diags
 	}
 
-	// In the case of a normal read, ID will already be set and this is redundant.
-	// In the case of a `terraform import`, we need to set ID since it was previously TYPE:SLUG.
-	// This must happen below the error checking in case the lookup failed.
-	data.SetId(errorLogger.Id)
-
 	diags = utils.DiagsCheckError(diags, data.Set("integration_id", errorLogger.IntegrationId), "Unable to read ErrorLogger integration_id")
 	diags = utils.DiagsCheckError(diags, data.Set("destination", errorLogger.Destination), "Unable to read ErrorLogger destination")
 
+	diags = append(diags, setMetadata(data, "ErrorLogger", errorLogger.Destination, errorLogger.Metadata)...)
+
 	return diags
 }
 
+// errorLoggerPatchFields are the ErrorLogger fields sent when their attributes change.
+var errorLoggerPatchFields = patchFields{
+	"integration_id": {"integration_id"},
+	"destination":    {"destination"},
+}
+
 func updateErrorLogger(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 
-	errorLogger := client.ErrorLogger{
-		Id:            data.Id(),
-		IntegrationId: data.Get("integration_id").(string),
-		Destination:   data.Get("destination").(string),
+	errorLogger := buildErrorLogger(data)
+	errorLogger.Id = data.Id()
+	patch, err := buildPatch(data, errorLogger, errorLoggerPatchFields)
+	if err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to update ErrorLogger"))
+		return diags
 	}
-	if _, err := c.ErrorLogger.Update(errorLogger); err != nil {
+	if len(patch) == 0 {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).ErrorLogger.Patch(data.Id(), patch); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to update ErrorLogger"))
 	}
 
@@ -103,10 +124,14 @@ func updateErrorLogger(_ context.Context, data *schema.ResourceData, meta interf
 
 func deleteErrorLogger(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	if _, err := c.ErrorLogger.Delete(id); err != nil {
+	if diags = checkDeletion(data, c, "ErrorLogger", errorLoggerDependents); diags.HasError() {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).ErrorLogger.Delete(id); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to delete ErrorLogger"))
 	}
 
@@ -0,0 +1,437 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// promptFieldTypes are the valid values of a prompt field's `type`.
+var promptFieldTypes = []string{"string", "int", "bool", "duration", "slack_user", "slack_user_list", "str_list", "int_list"}
+
+// flowParamDefaults and promptFieldParamDefaults are the defaults of the bool params of a Flow and its prompt
+// fields, keyed by their API names. Every other param defaults to being unset.
+var (
+	flowParamDefaults = map[string]bool{
+		"allow_revoke":             true,
+		"include_decision_message": false,
+		"schedule_deescalation":    true,
+		"allow_guest_interaction":  false,
+	}
+	promptFieldParamDefaults = map[string]bool{
+		"required": true,
+		"visible":  true,
+		"prefetch": false,
+	}
+)
+
+// FlowParamDefaults returns every param of a Flow supported by sym_flow, keyed by its API name and mapped to its
+// default, or to nil if it has none. The params of each prompt field are returned separately.
+func FlowParamDefaults() (params, promptFieldParams map[string]interface{}) {
+	withDefaults := func(names map[string]bool, defaults map[string]bool) map[string]interface{} {
+		result := map[string]interface{}{}
+		for name := range names {
+			if value, ok := defaults[name]; ok {
+				result[name] = value
+			} else {
+				result[name] = nil
+			}
+		}
+		return result
+	}
+	return withDefaults(flowParamNames, flowParamDefaults), withDefaults(promptFieldParamNames, promptFieldParamDefaults)
+}
+
+// flowParamBool returns the schema of a bool param with a default from the given defaults.
+func flowParamBool(defaults map[string]bool, name, description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(defaults[name]),
+		Description: description,
+	}
+}
+
+// flowParamsBlock returns the schema of the `params` block of sym_flow.
+func flowParamsBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "A set of parameters which configure the Flow.",
+		Attributes: map[string]schema.Attribute{
+			"strategy_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of a sym_strategy with sym_targets that this sym_flow will be managing access to. If not defined, this sym_flow will be approval-only.",
+			},
+			"allow_revoke": flowParamBool(flowParamDefaults, "allow_revoke",
+				`Whether access granted by a sym_strategy may be revoked before the requested duration is over. If true, shows a "Revoke" button in Slack that allows both the requester and approver to instantly revoke access. At least one of "schedule_deescalation" or "allow_revoke" must be true.`),
+			"include_decision_message": flowParamBool(flowParamDefaults, "include_decision_message",
+				`Whether users responding to requests may enter additional text as context for their decisions. If true, shows an input box on all open requests.`),
+			"allowed_sources": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: `A set of sources from which this sym_flow may be invoked. Valid sources are: "slack", "api". If unspecified, all sources will be enabled. If an empty list is specified, it will not be possible for this sym_flow to be invoked.`,
+			},
+			"schedule_deescalation": flowParamBool(flowParamDefaults, "schedule_deescalation",
+				`Whether automatic access de-escalation will occur after a requested duration. If false, de-escalation will only occur when manually revoked. At least one of "schedule_deescalation" or "allow_revoke" must be true.`),
+			"additional_header_text": schema.StringAttribute{
+				Optional:    true,
+				Description: "Additional text to append to the header text displayed at the top of the Slack request modal, after the default header text. Supports Slack markdown.",
+			},
+			"allow_guest_interaction": flowParamBool(flowParamDefaults, "allow_guest_interaction",
+				`Whether to allow guest users to interact with this sym_flow. If true, guest users can click the "Approve", "Deny", and "Revoke" buttons in Slack. If false, guest users' interactions with this sym_flow's requests will be rejected.`),
+		},
+		Blocks: map[string]schema.Block{
+			"prompt_field": schema.ListNestedBlock{
+				Description: "Custom input field used to collect information from a user who is requesting access to a resource.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{Required: true, Description: "A unique identifier for this field."},
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: `The type of data stored in this field. One of: "string", "str_list", "int", "int_list", "bool", "duration", "slack_user", "slack_user_list".`,
+							Validators:  []validator.String{stringvalidator.OneOf(promptFieldTypes...)},
+						},
+						"required": flowParamBool(promptFieldParamDefaults, "required", "Whether this field is a required input."),
+						"label":    schema.StringAttribute{Optional: true, Description: "A name for the field, to be displayed in Slack."},
+						"default": schema.StringAttribute{
+							Optional:    true,
+							Description: `A fallback value for optional fields if no value is provided. Not applicable for the "slack_user", "slack_user_list", "int_list", and "str_list" types.`,
+						},
+						"visible": flowParamBool(promptFieldParamDefaults, "visible", "Whether this field is rendered in the prompt modal."),
+						"allowed_values": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Description: `Defines the full list of valid choices for this field's value. If defined, this field will be displayed as a dropdown in Slack. Not applicable for the "slack_user" and "slack_user_list" types.`,
+						},
+						"prefetch": flowParamBool(promptFieldParamDefaults, "prefetch",
+							`Whether a prefetch reducer will be used to populate the options for this field. Not applicable for the "slack_user" and "slack_user_list" types.`),
+						"on_change": schema.StringAttribute{
+							CustomType:  implementationType{},
+							Optional:    true,
+							Description: "Python code defining logic to execute when this field's value changes.",
+							Validators:  []validator.String{implementationValidator{}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// flowParamsModel is the `params` block of sym_flow.
+type flowParamsModel struct {
+	StrategyId             types.String       `tfsdk:"strategy_id"`
+	AllowRevoke            types.Bool         `tfsdk:"allow_revoke"`
+	IncludeDecisionMessage types.Bool         `tfsdk:"include_decision_message"`
+	AllowedSources         types.Set          `tfsdk:"allowed_sources"`
+	ScheduleDeescalation   types.Bool         `tfsdk:"schedule_deescalation"`
+	PromptFields           []promptFieldModel `tfsdk:"prompt_field"`
+	AdditionalHeaderText   types.String       `tfsdk:"additional_header_text"`
+	AllowGuestInteraction  types.Bool         `tfsdk:"allow_guest_interaction"`
+}
+
+// promptFieldModel is a `prompt_field` block of the sym_flow `params` block.
+type promptFieldModel struct {
+	Name          types.String        `tfsdk:"name"`
+	Type          types.String        `tfsdk:"type"`
+	Required      types.Bool          `tfsdk:"required"`
+	Label         types.String        `tfsdk:"label"`
+	Default       types.String        `tfsdk:"default"`
+	Visible       types.Bool          `tfsdk:"visible"`
+	AllowedValues types.List          `tfsdk:"allowed_values"`
+	Prefetch      types.Bool          `tfsdk:"prefetch"`
+	OnChange      implementationValue `tfsdk:"on_change"`
+}
+
+// flowParamsJSON is the API representation of the params supported by sym_flow. Params which are not fields of
+// flowParamsJSON or promptFieldJSON are kept in `unmanaged_params`.
+type flowParamsJSON struct {
+	StrategyId             string            `json:"strategy_id,omitempty"`
+	AllowRevoke            *bool             `json:"allow_revoke,omitempty"`
+	IncludeDecisionMessage *bool             `json:"include_decision_message,omitempty"`
+	AllowedSources         *[]string         `json:"allowed_sources,omitempty"`
+	ScheduleDeescalation   *bool             `json:"schedule_deescalation,omitempty"`
+	PromptFields           []promptFieldJSON `json:"prompt_fields"`
+	AdditionalHeaderText   string            `json:"additional_header_text"`
+	AllowGuestInteraction  *bool             `json:"allow_guest_interaction,omitempty"`
+}
+
+// promptFieldJSON is the API representation of a prompt field. Its `on_change` implementation is base64 encoded.
+type promptFieldJSON struct {
+	Name          string      `json:"name"`
+	Type          string      `json:"type"`
+	Required      *bool       `json:"required,omitempty"`
+	Label         string      `json:"label"`
+	Default       interface{} `json:"default"`
+	Visible       *bool       `json:"visible,omitempty"`
+	AllowedValues []string    `json:"allowed_values"`
+	Prefetch      *bool       `json:"prefetch,omitempty"`
+	OnChange      string      `json:"on_change"`
+}
+
+// flowParamNames and promptFieldParamNames are the API names of the params supported by sym_flow.
+var (
+	flowParamNames        = jsonFieldNames(flowParamsJSON{})
+	promptFieldParamNames = jsonFieldNames(promptFieldJSON{})
+)
+
+// jsonFieldNames returns the JSON names of the fields of a struct.
+func jsonFieldNames(v interface{}) map[string]bool {
+	names := map[string]bool{}
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		names[name] = true
+	}
+	return names
+}
+
+// apiParams returns the params sent to the Sym API for the `params` block, which must be wholly known.
+func (p *flowParamsModel) apiParams(ctx context.Context) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// If no params were defined, make sure we still send an empty params blob to the API.
+	params := map[string]interface{}{}
+	if p == nil {
+		return params, diags
+	}
+
+	encoded := flowParamsJSON{
+		// An empty strategy_id is omitted, or the API will be unhappy.
+		StrategyId:             p.StrategyId.ValueString(),
+		AllowRevoke:            p.AllowRevoke.ValueBoolPointer(),
+		IncludeDecisionMessage: p.IncludeDecisionMessage.ValueBoolPointer(),
+		ScheduleDeescalation:   p.ScheduleDeescalation.ValueBoolPointer(),
+		PromptFields:           make([]promptFieldJSON, len(p.PromptFields)),
+		AdditionalHeaderText:   p.AdditionalHeaderText.ValueString(),
+		AllowGuestInteraction:  p.AllowGuestInteraction.ValueBoolPointer(),
+	}
+
+	// An explicit empty list of allowed_sources means that the Flow is not invokable by any method, Slack or API.
+	// However, unset allowed_sources lets the Sym platform decide the default, so they are not sent at all.
+	if !p.AllowedSources.IsNull() {
+		sources, sourcesDiags := stringSet(ctx, p.AllowedSources)
+		diags.Append(sourcesDiags...)
+		encoded.AllowedSources = &sources
+	}
+
+	for i, field := range p.PromptFields {
+		allowedValues, valuesDiags := stringList(ctx, field.AllowedValues)
+		diags.Append(valuesDiags...)
+
+		encoded.PromptFields[i] = promptFieldJSON{
+			Name:          field.Name.ValueString(),
+			Type:          field.Type.ValueString(),
+			Required:      field.Required.ValueBoolPointer(),
+			Label:         field.Label.ValueString(),
+			Default:       field.Default.ValueString(),
+			Visible:       field.Visible.ValueBoolPointer(),
+			AllowedValues: allowedValues,
+			Prefetch:      field.Prefetch.ValueBoolPointer(),
+			// The state keeps the human-readable on_change implementation, but the Sym API expects it base64 encoded.
+			OnChange: base64.StdEncoding.EncodeToString([]byte(field.OnChange.ValueString())),
+		}
+	}
+
+	// Params are sent as a map, so that params recorded in `unmanaged_params` may be merged into them.
+	b, err := json.Marshal(encoded)
+	if err == nil {
+		err = json.Unmarshal(b, &params)
+	}
+	if err != nil {
+		diags.AddError("Unable to encode Flow params", err.Error())
+	}
+	return params, diags
+}
+
+// stringList returns the elements of a list of strings, or an empty slice if it is null.
+func stringList(ctx context.Context, l types.List) ([]string, diag.Diagnostics) {
+	values := []string{}
+	if l.IsNull() {
+		return values, nil
+	}
+
+	diags := l.ElementsAs(ctx, &values, false)
+	if values == nil {
+		values = []string{}
+	}
+	return values, diags
+}
+
+// stringSet returns the elements of a set of strings, sorted so that they are always sent to Sym in the same order.
+func stringSet(ctx context.Context, s types.Set) ([]string, diag.Diagnostics) {
+	values := []string{}
+	if s.IsNull() {
+		return values, nil
+	}
+
+	diags := s.ElementsAs(ctx, &values, false)
+	if values == nil {
+		values = []string{}
+	}
+	sort.Strings(values)
+	return values, diags
+}
+
+// readFlowParams converts the params read from Sym into the `params` block, and returns the params this provider
+// does not support separately, to be stored in `unmanaged_params`.
+//
+// Values which Sym returns when a param is unset, e.g. an empty label, are only kept if the prior params had them,
+// so that params which are not configured do not cause a diff. If neither the prior params nor Sym have any
+// supported params, the block is left unset.
+func readFlowParams(params map[string]interface{}, prior *flowParamsModel) (*flowParamsModel, map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	unmanagedParams := map[string]string{}
+	known := 0
+
+	// The API may add new params that this provider doesn't know about yet. They are kept in
+	// `unmanaged_params` so that updates can send them back unchanged.
+	for key, value := range params {
+		if !flowParamNames[key] {
+			diags.Append(frameworkDiags(setUnmanagedParam(unmanagedParams, key, value))...)
+			continue
+		}
+		known++
+
+		if key != "prompt_fields" {
+			continue
+		}
+		promptFields, _ := value.([]interface{})
+		for _, p := range promptFields {
+			promptField, _ := p.(map[string]interface{})
+			for fieldKey, fieldValue := range promptField {
+				if !promptFieldParamNames[fieldKey] {
+					diags.Append(frameworkDiags(setUnmanagedParam(unmanagedParams, unmanagedPromptFieldParamKey(promptField["name"], fieldKey), fieldValue))...)
+				}
+			}
+		}
+	}
+
+	if prior == nil && known == 0 {
+		return nil, unmanagedParams, diags
+	}
+
+	var decoded flowParamsJSON
+	b, err := json.Marshal(params)
+	if err == nil {
+		err = json.Unmarshal(b, &decoded)
+	}
+	if err != nil {
+		diags.AddError("Unable to read Flow params", err.Error())
+		return prior, unmanagedParams, diags
+	}
+
+	model, modelDiags := decoded.model(prior)
+	diags.Append(modelDiags...)
+	return model, unmanagedParams, diags
+}
+
+// model returns the `params` block for the params read from Sym. See readFlowParams.
+func (p flowParamsJSON) model(prior *flowParamsModel) (*flowParamsModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if prior == nil {
+		prior = &flowParamsModel{}
+	}
+
+	model := &flowParamsModel{
+		StrategyId:             unsetString(p.StrategyId, prior.StrategyId),
+		AllowRevoke:            boolParam(p.AllowRevoke, flowParamDefaults["allow_revoke"]),
+		IncludeDecisionMessage: boolParam(p.IncludeDecisionMessage, flowParamDefaults["include_decision_message"]),
+		AllowedSources:         types.SetNull(types.StringType),
+		ScheduleDeescalation:   boolParam(p.ScheduleDeescalation, flowParamDefaults["schedule_deescalation"]),
+		PromptFields:           make([]promptFieldModel, len(p.PromptFields)),
+		AdditionalHeaderText:   unsetString(p.AdditionalHeaderText, prior.AdditionalHeaderText),
+		AllowGuestInteraction:  boolParam(p.AllowGuestInteraction, flowParamDefaults["allow_guest_interaction"]),
+	}
+	if p.AllowedSources != nil {
+		model.AllowedSources = setParam(*p.AllowedSources)
+	}
+
+	for i, field := range p.PromptFields {
+		priorField := promptFieldModel{}
+		if i < len(prior.PromptFields) {
+			priorField = prior.PromptFields[i]
+		}
+
+		defaultValue := ""
+		if s, ok := field.Default.(string); ok {
+			defaultValue = s
+		} else if field.Default != nil {
+			defaultValue = fmt.Sprint(field.Default)
+		}
+
+		model.PromptFields[i] = promptFieldModel{
+			Name:          types.StringValue(field.Name),
+			Type:          types.StringValue(field.Type),
+			Required:      boolParam(field.Required, promptFieldParamDefaults["required"]),
+			Label:         unsetString(field.Label, priorField.Label),
+			Default:       unsetString(defaultValue, priorField.Default),
+			Visible:       boolParam(field.Visible, promptFieldParamDefaults["visible"]),
+			AllowedValues: types.ListNull(types.StringType),
+			Prefetch:      boolParam(field.Prefetch, promptFieldParamDefaults["prefetch"]),
+			OnChange:      newImplementationNull(),
+		}
+		if len(field.AllowedValues) > 0 || !priorField.AllowedValues.IsNull() {
+			model.PromptFields[i].AllowedValues = listParam(field.AllowedValues)
+		}
+
+		// Convert base64 encoded on_change implementations back to human-readable Python code.
+		decoded, err := base64.StdEncoding.DecodeString(field.OnChange)
+		if err != nil {
+			diags.AddError("Unable to read on_change implementation", err.Error())
+		} else if len(decoded) > 0 || !priorField.OnChange.IsNull() {
+			model.PromptFields[i].OnChange = newImplementationValue(string(decoded))
+		}
+	}
+
+	return model, diags
+}
+
+// unsetString returns a string read from Sym, or null if it is empty and was null before.
+func unsetString(value string, prior types.String) types.String {
+	if value == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// boolParam returns the value of a bool param, or its default if Sym did not return it.
+func boolParam(value *bool, defaultValue bool) types.Bool {
+	if value == nil {
+		return types.BoolValue(defaultValue)
+	}
+	return types.BoolValue(*value)
+}
+
+// listParam returns the value of a list of strings param.
+func listParam(values []string) types.List {
+	elements := make([]attr.Value, len(values))
+	for i, value := range values {
+		elements[i] = types.StringValue(value)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// setParam returns the value of a set of strings param, without any duplicate values read from Sym.
+func setParam(values []string) types.Set {
+	seen := map[string]bool{}
+	elements := []attr.Value{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			elements = append(elements, types.StringValue(value))
+		}
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
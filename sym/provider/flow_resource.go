@@ -1,6 +1,6 @@
 // Each resource must implement a Resource interface provided by Hashicorp.
 //
-// This file contains the implementation of the Flow Resource
+// This file contains the implementation of the Flow Resource, which is served by the framework provider.
 package provider
 
 import (
@@ -13,196 +13,153 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
-	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/symopsio/terraform-provider-sym/sym/client"
 	"github.com/symopsio/terraform-provider-sym/sym/utils"
 )
 
-// Flow represents a Sym Flow
-func Flow() *schema.Resource {
-	return &schema.Resource{
-		Description:   "The `sym_flow` resource defines an approval workflow in Sym, allowing users to request temporary and auto-expiring access to sensitive resources.",
-		Schema:        flowSchema(),
-		CreateContext: createFlow,
-		ReadContext:   readFlow,
-		UpdateContext: updateFlow,
-		DeleteContext: deleteFlow,
-		Importer: &schema.ResourceImporter{
-			StateContext: getSlugImporter("flow"),
-		},
-		SchemaVersion: 1,
-		StateUpgraders: []schema.StateUpgrader{
-			{
-				Type:    flowResourceV0().CoreConfigSchema().ImpliedType(),
-				Upgrade: flowResourceStateUpgradeV0,
-				Version: 0,
-			},
-		},
-	}
+var (
+	_ resource.ResourceWithConfigure        = &symFlowResource{}
+	_ resource.ResourceWithConfigValidators = &symFlowResource{}
+	_ resource.ResourceWithValidateConfig   = &symFlowResource{}
+	_ resource.ResourceWithModifyPlan       = &symFlowResource{}
+	_ resource.ResourceWithImportState      = &symFlowResource{}
+	_ resource.ResourceWithUpgradeState     = &symFlowResource{}
+)
+
+// symFlowResource implements sym_flow, which represents a Sym Flow.
+type symFlowResource struct {
+	frameworkResource
 }
 
-func promptFieldResource() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"name":     {Required: true, Type: schema.TypeString, Description: "A unique identifier for this field."},
-			"type":     {Required: true, Type: schema.TypeString, Description: `The type of data stored in this field. One of: "string", "str_list", "int", "int_list", "bool", "duration", "slack_user", "slack_user_list".`, ValidateDiagFunc: validatePromptFieldType},
-			"required": {Optional: true, Default: true, Type: schema.TypeBool, Description: "Whether this field is a required input."},
-			"label":    {Optional: true, Type: schema.TypeString, Description: "A name for the field, to be displayed in Slack."},
-			"default":  {Optional: true, Type: schema.TypeString, Description: "A fallback value for optional fields if no value is provided. Not applicable for the \"slack_user\", \"slack_user_list\", \"int_list\", and \"str_list\" types."},
-			"visible":  {Optional: true, Default: true, Type: schema.TypeBool, Description: "Whether this field is rendered in the prompt modal."},
-			"allowed_values": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
-				Optional:    true,
-				Description: "Defines the full list of valid choices for this field's value. If defined, this field will be displayed as a dropdown in Slack. Not applicable for the \"slack_user\" and \"slack_user_list\" types.",
-			},
-			"prefetch":  {Optional: true, Type: schema.TypeBool, Default: false, Description: "Whether a prefetch reducer will be used to populate the options for this field. Not applicable for the \"slack_user\" and \"slack_user_list\" types."},
-			"on_change": {Optional: true, Type: schema.TypeString, Description: "Python code defining logic to execute when this field's value changes.", ValidateDiagFunc: ImplementationValidation},
-		},
-		Description: "Custom input field used to collect information from a user who is requesting access to a resource.",
-	}
+func newFlowResource() resource.Resource {
+	return &symFlowResource{}
 }
 
-func flowParamsResource() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"strategy_id":              {Type: schema.TypeString, Optional: true, Description: "The ID of a sym_strategy with sym_targets that this sym_flow will be managing access to. If not defined, this sym_flow will be approval-only."},
-			"allow_revoke":             {Type: schema.TypeBool, Optional: true, Default: true, Description: `Whether access granted by a sym_strategy may be revoked before the requested duration is over. If true, shows a "Revoke" button in Slack that allows both the requester and approver to instantly revoke access. At least one of "schedule_deescalation" or "allow_revoke" must be true.`},
-			"include_decision_message": {Type: schema.TypeBool, Optional: true, Default: false, Description: `Whether users responding to requests may enter additional text as context for their decisions. If true, shows an input box on all open requests.`},
-			"allowed_sources": {
-				Type:        schema.TypeList,
-				Elem:        &schema.Schema{Type: schema.TypeString},
+func (r *symFlowResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_flow"
+}
+
+func (r *symFlowResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = flowSchema()
+}
+
+// flowSchema returns the schema of sym_flow. Version 2 replaced the list of one `params` block with a single block,
+// and added `implementation_wo`. Version 3 made `params.allowed_sources` a set. See UpgradeState.
+func flowSchema() schema.Schema {
+	return schema.Schema{
+		Description: "The `sym_flow` resource defines an approval workflow in Sym, allowing users to request temporary and auto-expiring access to sensitive resources.",
+		Version:     3,
+		Attributes: withFrameworkMetadata(map[string]schema.Attribute{
+			"id":        idAttribute(),
+			"org":       orgAttribute(),
+			"name":      schema.StringAttribute{Required: true, Description: "A unique identifier for the Flow."},
+			"full_name": fullNameAttribute(),
+			"label":     schema.StringAttribute{Optional: true, Description: "An optional label for the Flow."},
+			"implementation": schema.StringAttribute{
+				CustomType:  implementationType{},
 				Optional:    true,
-				Description: `A list of sources from which this sym_flow may be invoked. Valid sources are: "slack", "api". If unspecified, all sources will be enabled. If an empty list is specified, it will not be possible for this sym_flow to be invoked.`,
+				Description: "Python code defining custom logic for the Flow. Exactly one of `implementation` and `implementation_wo` must be set.",
+				Validators:  []validator.String{implementationValidator{}},
+			},
+			"implementation_wo": schema.StringAttribute{
+				Optional:  true,
+				WriteOnly: true,
+				Description: "Python code defining custom logic for the Flow, which is never stored in the Terraform plan or state. " +
+					"Changes are detected by `implementation_hash` instead. Requires Terraform 1.11 or later.",
+				Validators: []validator.String{implementationValidator{}},
+			},
+			"implementation_hash": schema.StringAttribute{
+				Computed:      true,
+				Description:   "A SHA-256 hash of the Flow's implementation, from either `implementation` or `implementation_wo`.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"schedule_deescalation": {Type: schema.TypeBool, Optional: true, Default: true, Description: `Whether automatic access de-escalation will occur after a requested duration. If false, de-escalation will only occur when manually revoked. At least one of "schedule_deescalation" or "allow_revoke" must be true.`},
-			"prompt_field": {
-				Type:        schema.TypeList,
+			"vars": schema.MapAttribute{
+				ElementType: types.StringType,
 				Optional:    true,
-				Elem:        promptFieldResource(),
-				Description: "Custom input field used to collect information from a user who is requesting access to a resource.",
+				Computed:    true,
+				Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, nil)),
+				Description: "A map of variables and their string values to pass to `impl.py`. Useful for making IDs generated dynamically by Terraform available to your `impl.py`.\n\n" +
+					"~> **Note:** While you may pass in other primitives (e.g. bool, int) as a value to `sym_flow.vars`, they will be cast to strings when you apply your configuration. " +
+					"When accessing these values in your `impl.py`, you will need to recast them into the correct types before using them.",
 			},
-			"additional_header_text":  {Type: schema.TypeString, Optional: true, Description: "Additional text to append to the header text displayed at the top of the Slack request modal, after the default header text. Supports Slack markdown."},
-			"allow_guest_interaction": {Type: schema.TypeBool, Optional: true, Default: false, Description: `Whether to allow guest users to interact with this sym_flow. If true, guest users can click the "Approve", "Deny", and "Revoke" buttons in Slack. If false, guest users' interactions with this sym_flow's requests will be rejected.'`},
+			"environment_id": schema.StringAttribute{Required: true, Description: "The ID of the Environment this Flow is associated with."},
+			"unmanaged_params": schema.MapAttribute{
+				ElementType:   types.StringType,
+				Computed:      true,
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.UseStateForUnknown()},
+				Description: "Flow params set outside of Terraform (e.g. in the Sym web app) that this provider version does not support, as JSON-encoded values. " +
+					"Params for prompt fields are keyed by `prompt_fields.<field name>.<param>`. These params are preserved when the Flow is updated.",
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+		}),
+		Blocks: map[string]schema.Block{
+			"params": flowParamsBlock(),
 		},
-		Description: "A set of parameters which configure the Flow.",
 	}
 }
 
-// Map the resource's fields to types
-func flowSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"name":  utils.RequiredCaseInsensitiveString("A unique identifier for the Flow."),
-		"label": utils.Optional(schema.TypeString, "An optional label for the Flow."),
-		"implementation": {
-			Type:             schema.TypeString,
-			Required:         true,
-			ValidateDiagFunc: ImplementationValidation,
-			Description:      "Python code defining custom logic for the Flow.",
-		},
-		"vars": utils.SettingsMap(
-			"A map of variables and their string values to pass to `impl.py`. Useful for making IDs generated dynamically by Terraform available to your `impl.py`.\n\n" +
-				"~> **Note:** While you may pass in other primitives (e.g. bool, int) as a value to `sym_flow.vars`, they will be cast to strings when you apply your configuration. " +
-				"When accessing these values in your `impl.py`, you will need to recast them into the correct types before using them.",
-		),
-		"environment_id": utils.Required(schema.TypeString, "The ID of the Environment this Flow is associated with."),
-		"params": {
-			Description: "A set of parameters which configure the Flow.",
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1, // Nested blocks are always parsed by Terraform as lists, but we only ever want 1 params block.
-			Elem:        flowParamsResource(),
-		},
-	}
+// flowModel is the Terraform state of a sym_flow.
+type flowModel struct {
+	Id                 types.String        `tfsdk:"id"`
+	Org                types.String        `tfsdk:"org"`
+	Name               types.String        `tfsdk:"name"`
+	FullName           types.String        `tfsdk:"full_name"`
+	Label              types.String        `tfsdk:"label"`
+	Implementation     implementationValue `tfsdk:"implementation"`
+	ImplementationWO   types.String        `tfsdk:"implementation_wo"`
+	ImplementationHash types.String        `tfsdk:"implementation_hash"`
+	Vars               types.Map           `tfsdk:"vars"`
+	EnvironmentId      types.String        `tfsdk:"environment_id"`
+	Params             *flowParamsModel    `tfsdk:"params"`
+	UnmanagedParams    types.Map           `tfsdk:"unmanaged_params"`
+	DeletionProtection types.Bool          `tfsdk:"deletion_protection"`
+	metadataModel
 }
 
-// flowResourceV0 returns the Terraform schema for sym_flow for the provider version < 2.0.0
-// and is used to programmatically migrate users' state between the old version and the new.
-func flowResourceV0() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"name":     utils.RequiredCaseInsensitiveString("A unique identifier for the Flow."),
-			"label":    utils.Optional(schema.TypeString, "An optional label for the Flow."),
-			"template": utils.Required(schema.TypeString, "The SRN of the template this flow uses. E.g. 'sym:template:approval:1.0.0'"),
-			"implementation": {
-				Type:             schema.TypeString,
-				Required:         true,
-				DiffSuppressFunc: utils.SuppressEquivalentFileContentDiffs,
-				StateFunc: func(val interface{}) string {
-					return utils.ParseImpl(val.(string))
-				},
-				Description: "Relative path of the implementation file written in python.",
-			},
-			"vars":           utils.SettingsMap("A map of variables and their values to pass to `impl.py`. Useful for making IDs generated dynamically by Terraform available to your `impl.py`. "),
-			"environment_id": utils.Required(schema.TypeString, "The ID of the Environment this Flow is associated with."),
-			"params": {
-				Type:        schema.TypeMap,
-				Required:    true,
-				Description: "A set of parameters which configure the Flow. See the [Sym Documentation](https://docs.symops.com/docs/flow-parameters).",
-			},
-		},
+func (r *symFlowResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("implementation"), path.MatchRoot("implementation_wo")),
 	}
 }
 
-// flowResourceStateUpgradeV0 will programmatically migrate users' state from Terraform Provider < 2.0.0
-// to the version required by 2.0.0.
-func flowResourceStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-	// Remove `template` from state if present as it is no longer part of sym_flow.
-	delete(rawState, "template")
-
-	// Only migrate params if they're actually present in state.
-	if params, ok := rawState["params"].(map[string]interface{}); ok {
-		// Turn `allowed_sources_json` to an actual list at `allowed_sources`
-		if allowedSourcesJSONStr, ok := params["allowed_sources_json"].(string); ok {
-			// Parse the JSON string representing a list of strings and set that as the state
-			var allowedSources []string
-
-			// Ignore any json unmarshalling error and let go/tf raise it somewhere
-			_ = json.Unmarshal([]byte(allowedSourcesJSONStr), &allowedSources)
-			params["allowed_sources"] = allowedSources
-
-			// Delete the original JSON key
-			delete(params, "allowed_sources_json")
-		}
-
-		// Turn `prompt_fields_json` into a list at `prompt_field` that contains real maps.
-		if promptFieldsJSONStr, ok := params["prompt_fields_json"].(string); ok {
-			var promptFields []interface{}
-			_ = json.Unmarshal([]byte(promptFieldsJSONStr), &promptFields)
-
-			// Cast `allowed_values` within each field to []string instead of []interface{}
-			for i := range promptFields {
-				promptField := promptFields[i].(map[string]interface{})
-
-				// All values must be cast to string individually, so build a new list
-				// by iterating over the old one and casting each value to a string.
-				if allowedValuesOriginal, ok := promptField["allowed_values"]; ok {
-					var stringAllowedValues []string
-
-					if allowedValues, ok := allowedValuesOriginal.([]interface{}); ok {
-						for j := range allowedValues {
-							stringAllowedValues = append(stringAllowedValues, allowedValues[j].(string))
-						}
-					}
-
-					promptField["allowed_values"] = stringAllowedValues
-				}
-			}
-
-			params["prompt_field"] = promptFields
+// ValidateConfig warns users if they're using allowed_values for a prompt_field named "target_id", since it will
+// be ignored by the Sym platform.
+func (r *symFlowResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var promptFields types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("params").AtName("prompt_field"), &promptFields)...)
+	if resp.Diagnostics.HasError() || promptFields.IsNull() || promptFields.IsUnknown() {
+		return
+	}
 
-			// Delete the original JSON key
-			delete(params, "prompt_fields_json")
+	for i, element := range promptFields.Elements() {
+		promptField, ok := element.(types.Object)
+		if !ok {
+			continue
+		}
+		name, _ := promptField.Attributes()["name"].(types.String)
+		allowedValues, _ := promptField.Attributes()["allowed_values"].(types.List)
+		if name.ValueString() == "target_id" && len(allowedValues.Elements()) > 0 {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("params").AtName("prompt_field").AtListIndex(i).AtName("allowed_values"),
+				fmt.Sprintf("params.prompt_field.%v.allowed_values will be ignored", i),
+				"prompt_fields named 'target_id' have auto-populated allowed_values, so the defined allowed_values will be ignored.",
+			)
 		}
-
-		// Params used to be a map, and is now a list with one map element in it.
-		rawState["params"] = []interface{}{params}
 	}
-
-	return rawState, nil
 }
 
 // ImplementationValidation validates that a given implementation for a sym_flow is
@@ -250,284 +207,469 @@ func checkFlowVars(vars map[string]string) diag.Diagnostics {
 	return diags
 }
 
-func createFlow(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+// flowImportCandidates lists every Flow that may be imported as a sym_flow.
+var flowImportCandidates = importCandidatesOf(
+	func(c *client.ApiClient) ([]client.Flow, error) { return c.Flow.List(nil) },
+	func(flow client.Flow) importCandidate { return importCandidate{Id: flow.Id, Slug: flow.Name} },
+)
 
-	params, paramDiags := getAPISafeParams(data.Get("params").([]interface{}), data)
-	diags = append(diags, paramDiags...)
+// flowImplementation returns the configured `implementation` or `implementation_wo`. Write-only attributes are
+// null in the plan, so both are read from the config.
+func flowImplementation(ctx context.Context, config tfsdk.Config) (types.String, fwdiag.Diagnostics) {
+	var (
+		implementation   implementationValue
+		implementationWO types.String
+	)
+	diags := config.GetAttribute(ctx, path.Root("implementation"), &implementation)
+	diags.Append(config.GetAttribute(ctx, path.Root("implementation_wo"), &implementationWO)...)
 
-	vars := getSettingsMap(data, "vars")
-	diags = append(diags, checkFlowVars(vars)...)
+	if !implementationWO.IsNull() {
+		return implementationWO, diags
+	}
+	return implementation.StringValue, diags
+}
+
+// implementationHash returns the `implementation_hash` of the given implementation.
+func implementationHash(implementation types.String) types.String {
+	if implementation.IsNull() || implementation.IsUnknown() {
+		return implementation
+	}
+	return types.StringValue(utils.HashImpl(implementation.ValueString()))
+}
+
+// flow returns the Flow configured by the plan, which must be wholly known. The implementations are read from the
+// config, since `implementation_wo` is never planned, and the planned implementations are hashes if the provider's
+// `hash_implementations` setting is enabled.
+func (f *flowModel) flow(ctx context.Context, m *providerMeta, config tfsdk.Config) (client.Flow, fwdiag.Diagnostics) {
+	implementation, diags := flowImplementation(ctx, config)
+
+	params := f.Params
+	if params != nil {
+		configured := *params
+		configured.PromptFields = make([]promptFieldModel, len(params.PromptFields))
+		for i, field := range params.PromptFields {
+			diags.Append(config.GetAttribute(ctx, onChangePath(i), &field.OnChange)...)
+			configured.PromptFields[i] = field
+		}
+		params = &configured
+	}
+	apiParams, paramsDiags := params.apiParams(ctx)
+	diags.Append(paramsDiags...)
+
+	vars := client.Settings{}
+	diags.Append(f.Vars.ElementsAs(ctx, &vars, false)...)
+	diags.Append(frameworkDiags(checkFlowVars(vars))...)
 
 	flow := client.Flow{
-		Name:          data.Get("name").(string),
-		Label:         data.Get("label").(string),
-		EnvironmentId: data.Get("environment_id").(string),
+		Id:            f.Id.ValueString(),
+		Name:          m.Naming.fullName(f.Name.ValueString()),
+		Label:         m.Naming.fullLabel(f.Label.ValueString()),
+		EnvironmentId: f.EnvironmentId.ValueString(),
 		Vars:          vars,
-		Params:        params,
+		Params:        apiParams,
 	}
 
 	// The Sym API stores and communicates Flow implementations in base64 to keep the payload smaller.
-	implementation := data.Get("implementation").(string)
-	flow.Implementation = base64.StdEncoding.EncodeToString([]byte(implementation))
+	flow.Implementation = base64.StdEncoding.EncodeToString([]byte(implementation.ValueString()))
+
+	return flow, diags
+}
+
+// onChangePath returns the path of the `on_change` implementation of the prompt field at the given index.
+func onChangePath(i int) path.Path {
+	return path.Root("params").AtName("prompt_field").AtListIndex(i).AtName("on_change")
+}
 
-	if diags.HasError() {
+// planImplementationState plans the implementation at the given path as it is stored in the Terraform state: as a
+// hash if the provider's `hash_implementations` setting is enabled, or as configured if a hash from when the setting
+// was enabled would otherwise be kept. See implementationState.
+func planImplementationState(ctx context.Context, m *providerMeta, config tfsdk.Config, plan *tfsdk.Plan, p path.Path) fwdiag.Diagnostics {
+	var configured, planned implementationValue
+	diags := config.GetAttribute(ctx, p, &configured)
+	diags.Append(plan.GetAttribute(ctx, p, &planned)...)
+	if diags.HasError() || configured.IsNull() || configured.IsUnknown() {
 		return diags
 	}
 
-	if id, err := c.Flow.Create(flow); err != nil {
-		diags = append(diags, utils.DiagFromError(err, "Unable to create Flow"))
-	} else {
-		data.SetId(id)
+	if m.HashImplementations || utils.IsHashedValue(planned.ValueString()) {
+		diags.Append(plan.SetAttribute(ctx, p, configured.stateValue(m))...)
 	}
-
 	return diags
 }
 
-func readFlow(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var (
-		diags diag.Diagnostics
-		flow  *client.Flow
-		err   error
-	)
-	c := meta.(*client.ApiClient)
-	id := data.Id()
+// unmanagedParams returns the params recorded in `unmanaged_params`.
+func (f *flowModel) unmanagedParams(ctx context.Context) (map[string]string, fwdiag.Diagnostics) {
+	unmanagedParams := map[string]string{}
+	if f.UnmanagedParams.IsNull() || f.UnmanagedParams.IsUnknown() {
+		return unmanagedParams, nil
+	}
+	diags := f.UnmanagedParams.ElementsAs(ctx, &unmanagedParams, false)
+	return unmanagedParams, diags
+}
 
-	if _, parseErr := uuid.ParseUUID(id); parseErr == nil {
-		// If the ID is a UUID, look up the Flow directly.
-		flow, err = c.Flow.Read(id)
-	} else {
-		// Otherwise, we are probably in the context of a `terraform import` and should attempt
-		// to look up the Flow by slug.
-		flow, err = c.Flow.Find(id)
+// ModifyPlan plans `implementation_hash`, and `full_name` whenever `name` changes, warns with a diff of any change
+// to the implementation, plans hashed implementations if the provider's `hash_implementations` setting is enabled,
+// and validates the Flow with the Sym API if the provider's `validate_on_plan` setting is enabled.
+func (r *symFlowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// There is nothing to plan when the Flow is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	implementation, diags := flowImplementation(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("implementation_hash"), implementationHash(implementation))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planChanges(resp.Plan, req.State)("name") {
+		var name types.String
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+
+		fullName := types.StringUnknown()
+		if r.meta != nil && !name.IsUnknown() {
+			fullName = types.StringValue(r.meta.Naming.fullName(name.ValueString()))
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("full_name"), fullName)...)
+	}
+
+	// A write-only implementation is not in the state, so there is nothing to diff against.
+	if !req.State.Raw.IsNull() {
+		var oldImplementation, newImplementation implementationValue
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("implementation"), &oldImplementation)...)
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("implementation"), &newImplementation)...)
+		if !oldImplementation.IsNull() && !newImplementation.IsNull() && !newImplementation.IsUnknown() {
+			resp.Diagnostics.Append(frameworkDiags(implementationDiff("Flow", oldImplementation.ValueString(), newImplementation.ValueString()))...)
+		}
+	}
+
+	if r.meta == nil {
+		return
+	}
+
+	var promptFields types.List
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("params").AtName("prompt_field"), &promptFields)...)
+	resp.Diagnostics.Append(planImplementationState(ctx, r.meta, req.Config, &resp.Plan, path.Root("implementation"))...)
+	for i := range promptFields.Elements() {
+		resp.Diagnostics.Append(planImplementationState(ctx, r.meta, req.Config, &resp.Plan, onChangePath(i))...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateFrameworkPlan(ctx, r.meta, "Flow", flowPatchFields, resp.Plan, req.State, func(m *providerMeta) error {
+		var plan flowModel
+		if diags := resp.Plan.Get(ctx, &plan); diags.HasError() {
+			return frameworkDiagsError(diags)
+		}
+
+		flow, diags := plan.flow(ctx, m, req.Config)
+		if !req.State.Raw.IsNull() {
+			unmanagedParams, unmanagedDiags := plan.unmanagedParams(ctx)
+			diags.Append(unmanagedDiags...)
+			diags.Append(frameworkDiags(mergeUnmanagedParams(flow.Params, unmanagedParams))...)
+		}
+		if err := frameworkDiagsError(diags); err != nil {
+			return err
+		}
+		return m.Client.Flow.Validate(flow)
+	})...)
+}
+
+func (r *symFlowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan flowModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, end := traceOperation(ctx, "sym_flow", "create", plan.Name)
+	defer func() { end(plan.Id, resp.Diagnostics) }()
+
+	m, diags := r.metaFor(ctx, plan.Org)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	flow, diags := plan.flow(ctx, m, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := m.Client.Flow.Create(flow)
 	if err != nil {
-		if isNotFoundError(err) {
+		resp.Diagnostics.AddError("Unable to create Flow", err.Error())
+		return
+	}
+
+	plan.Id = types.StringValue(id)
+	plan.FullName = types.StringValue(flow.Name)
+	plan.UnmanagedParams = types.MapValueMust(types.StringType, nil)
+	// As for SDK resources, the metadata is left empty until the next refresh.
+	plan.metadataModel = metadataModel{}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *symFlowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state flowModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, end := traceOperation(ctx, "sym_flow", "read", state.Name)
+	defer func() { end(state.Id, resp.Diagnostics) }()
+
+	m, diags := r.metaFor(ctx, state.Org)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.Id.ValueString()
+	flow, err := m.Client.Flow.Read(id)
+	if err != nil {
+		if utils.IsNotFoundError(err) {
 			log.Println(notFoundWarning("Flow", id))
-			data.SetId("")
-			return nil
+			state.Id = types.StringNull()
+			resp.State.RemoveResource(ctx)
+			return
 		}
-		diags = append(diags, utils.DiagFromError(err, "Unable to read Flow"))
-		return diags
+		resp.Diagnostics.AddError("Unable to read Flow", err.Error())
+		return
 	}
 
-	// In the case of a normal read, ID will already be set and this is redundant.
-	// In the case of a `terraform import`, we need to set ID since it was previously TYPE:SLUG.
-	// This must happen below the error checking in case the lookup failed.
-	data.SetId(flow.Id)
+	resp.Diagnostics.Append(state.read(ctx, m, flow)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
 
-	diags = utils.DiagsCheckError(diags, data.Set("name", flow.Name), "Unable to read Flow name")
-	diags = utils.DiagsCheckError(diags, data.Set("label", flow.Label), "Unable to read Flow label")
-	diags = utils.DiagsCheckError(diags, data.Set("environment_id", flow.EnvironmentId), "Unable to read Flow environment_id")
-	diags = utils.DiagsCheckError(diags, data.Set("vars", flow.Vars), "Unable to read Flow vars")
+// read sets the state of the Flow read from Sym.
+//
+// The implementation is only stored in the state if it was before, since it is not when `implementation_wo` is
+// used, and it and any `on_change` implementations are stored as hashes if the provider's `hash_implementations`
+// setting is enabled. The params are likewise only stored if they were before, or if Sym has params which should be
+// configured. See readFlowParams and ImportState.
+func (f *flowModel) read(ctx context.Context, m *providerMeta, flow *client.Flow) fwdiag.Diagnostics {
+	var diags, d fwdiag.Diagnostics
+
+	f.Name = types.StringValue(m.Naming.readName(f.Name.ValueString(), flow.Name))
+	f.FullName = types.StringValue(flow.Name)
+	f.Label = unsetString(m.Naming.readLabel(f.Label.ValueString(), flow.Label), f.Label)
+	f.EnvironmentId = types.StringValue(flow.EnvironmentId)
+
+	vars := flow.Vars
+	if vars == nil {
+		vars = client.Settings{}
+	}
+	f.Vars, d = types.MapValueFrom(ctx, types.StringType, vars)
+	diags.Append(d...)
 
 	// Base64 -> Text
 	// The payload from the Sym API contains the implementation but base64 encoded, so we must decode it
 	// to diff against the state, which has the readable file contents.
-	if decoded, err := base64.StdEncoding.DecodeString(flow.Implementation); err == nil {
-		diags = utils.DiagsCheckError(diags, data.Set("implementation", string(decoded)), "Unable to read Flow implementation")
-	} else {
-		diags = append(diags, utils.DiagFromError(err, "Unable to read Flow implementation"))
-	}
-
-	// The API may add new parameters that we (the provider) don't know about yet, so rebuild the map and include
-	// only the parameters we do know about. Otherwise, the data.Set below will fail to set the Flow's state.
-	knownParams := map[string]interface{}{}
-	paramsSchema := flowParamsResource().Schema
-	promptFieldSchema := promptFieldResource().Schema
-
-	for paramKey, paramValue := range flow.Params {
-		if paramKey == "prompt_fields" {
-			// Do the same check for known parameters within each prompt field
-			var knownPromptFields []map[string]interface{}
-
-			// Iterate over each prompt field from the API response
-			for i := range paramValue.([]interface{}) {
-				promptField := paramValue.([]interface{})[i].(map[string]interface{})
-				knownPromptField := map[string]interface{}{}
-
-				// Iterate over each key/value pair in the prompt field and rebuild the
-				// prompt field including only known keys.
-				for promptFieldKey, promptFieldValue := range promptField {
-					if _, ok := promptFieldSchema[promptFieldKey]; ok {
-						// Convert base64 encoded on_change implementations back to human-readable Python code.
-						if promptFieldKey == "on_change" && promptFieldValue != nil {
-							if decoded, err := base64.StdEncoding.DecodeString(promptFieldValue.(string)); err == nil {
-								knownPromptField[promptFieldKey] = string(decoded)
-							} else {
-								diags = append(diags, utils.DiagFromError(err, "Unable to read on_change implementation"))
-							}
-						} else {
-							knownPromptField[promptFieldKey] = promptFieldValue
-						}
-					}
-				}
-
-				knownPromptFields = append(knownPromptFields, knownPromptField)
-			}
-
-			// The Terraform block is called "prompt_field", so that's what Terraform expects. The Sym
-			// API returns "prompt_fields", so change the key to "prompt_field" before giving it to Terraform.
-			knownParams["prompt_field"] = knownPromptFields
-		}
+	decoded, err := base64.StdEncoding.DecodeString(flow.Implementation)
+	if err != nil {
+		diags.AddError("Unable to read Flow implementation", err.Error())
+		return diags
+	}
+	f.ImplementationHash = types.StringValue(utils.HashImpl(string(decoded)))
+	if !f.Implementation.IsNull() {
+		f.Implementation = newImplementationValue(string(decoded)).stateValue(m)
+	}
 
-		// Include this particular param key/value pair only if the key is present in the known params Terraform schema.
-		if _, ok := paramsSchema[paramKey]; ok {
-			knownParams[paramKey] = paramValue
+	params, unmanagedParams, d := readFlowParams(flow.Params, f.Params)
+	diags.Append(d...)
+	if params != nil {
+		for i, field := range params.PromptFields {
+			params.PromptFields[i].OnChange = field.OnChange.stateValue(m)
 		}
 	}
+	f.Params = params
+	f.UnmanagedParams, d = types.MapValueFrom(ctx, types.StringType, unmanagedParams)
+	diags.Append(d...)
 
-	// Because sym_flow.params is a block, Terraform expects a list, even though there is only ever one item.
-	diags = utils.DiagsCheckError(diags, data.Set("params", []map[string]interface{}{knownParams}), "Unable to read Flow params")
+	diags.Append(f.metadataModel.read("Flow", flow.Name, flow.Metadata)...)
 
 	return diags
 }
 
-func updateFlow(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+// flowPatchFields are the Flow fields sent when their attributes change.
+var flowPatchFields = patchFields{
+	"slug":           {"name", "full_name"},
+	"label":          {"label"},
+	"implementation": {"implementation", "implementation_hash"},
+	"environment_id": {"environment_id"},
+	"vars":           {"vars"},
+	"params":         {"params", "unmanaged_params"},
+}
 
-	params, paramDiags := getAPISafeParams(data.Get("params").([]interface{}), data)
-	diags = append(diags, paramDiags...)
+func (r *symFlowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state flowModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	vars := getSettingsMap(data, "vars")
-	diags = append(diags, checkFlowVars(vars)...)
+	ctx, end := traceOperation(ctx, "sym_flow", "update", plan.Name)
+	defer func() { end(plan.Id, resp.Diagnostics) }()
 
-	flow := client.Flow{
-		Id:            data.Id(),
-		Name:          data.Get("name").(string),
-		Label:         data.Get("label").(string),
-		EnvironmentId: data.Get("environment_id").(string),
-		Vars:          vars,
-		Params:        params,
+	m, diags := r.metaFor(ctx, plan.Org)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// The Sym API stores and communicates Flow implementations in base64 to keep the payload smaller.
-	implementation := data.Get("implementation").(string)
-	flow.Implementation = base64.StdEncoding.EncodeToString([]byte(implementation))
+	flow, diags := plan.flow(ctx, m, req.Config)
+	resp.Diagnostics.Append(diags...)
 
-	if _, err := c.Flow.Update(flow); err != nil {
-		diags = append(diags, utils.DiagFromError(err, "Unable to update Flow"))
+	// Send back any params set outside of Terraform, so they aren't wiped by this update.
+	unmanagedParams, diags := state.unmanagedParams(ctx)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(frameworkDiags(mergeUnmanagedParams(flow.Params, unmanagedParams))...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return diags
+	patch, err := patchOf(flow, flowPatchFields, planChanges(req.Plan, req.State))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to update Flow", err.Error())
+		return
+	}
+
+	plan.FullName = types.StringValue(flow.Name)
+	plan.UnmanagedParams = state.UnmanagedParams
+	if len(patch) == 0 {
+		// Nothing was sent to Sym, e.g. because only deletion_protection changed.
+		plan.metadataModel = state.metadataModel
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	if _, err := m.Client.WithETag(state.ETag.ValueString()).Flow.Patch(plan.Id.ValueString(), patch); err != nil {
+		resp.Diagnostics.AddError("Unable to update Flow", err.Error())
+		return
+	}
+
+	plan.metadataModel = metadataModel{}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *symFlowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state flowModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, end := traceOperation(ctx, "sym_flow", "delete", state.Name)
+	defer func() { end(state.Id, resp.Diagnostics) }()
+
+	m, diags := r.metaFor(ctx, state.Org)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.DeletionProtection.ValueBool() {
+		resp.Diagnostics.Append(frameworkDiags(diag.Diagnostics{deletionProtectedError("Flow")})...)
+		return
+	}
+
+	if _, err := m.Client.WithETag(state.ETag.ValueString()).Flow.Delete(state.Id.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete Flow", err.Error())
+	}
+}
+
+// ImportState resolves the identifier passed to `terraform import` as getImporter does, including any `@ORG`
+// suffix (see orgImporter). The implementation and params are set to empty values, so that Read populates them.
+func (r *symFlowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	identifier, org := r.meta.splitImportOrg(req.ID)
+	orgValue := types.StringNull()
+	if org != "" {
+		orgValue = types.StringValue(org)
+	}
+
+	m, diags := r.metaFor(ctx, orgValue)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := importId("flow", identifier, m.Client, flowImportCandidates)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import Flow", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org"), orgValue)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("implementation"), newImplementationValue(""))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("params"), &flowParamsModel{
+		AllowedSources: types.SetNull(types.StringType),
+	})...)
 }
 
-func deleteFlow(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// unmanagedPromptFieldParamKey returns the `unmanaged_params` key for an unknown param of the named prompt field.
+func unmanagedPromptFieldParamKey(fieldName interface{}, paramKey string) string {
+	return fmt.Sprintf("prompt_fields.%v.%s", fieldName, paramKey)
+}
+
+// setUnmanagedParam JSON-encodes the value of a param unknown to this provider into unmanagedParams.
+func setUnmanagedParam(unmanagedParams map[string]string, key string, value interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
-	id := data.Id()
 
-	if _, err := c.Flow.Delete(id); err != nil {
-		diags = append(diags, utils.DiagFromError(err, "Unable to delete Flow"))
+	if encoded, err := json.Marshal(value); err == nil {
+		unmanagedParams[key] = string(encoded)
+	} else {
+		diags = append(diags, utils.DiagFromError(err, fmt.Sprintf("Unable to read Flow param %s", key)))
 	}
 
 	return diags
 }
 
-// getAPISafeParams takes in the paramsList that Terraform constructs from a user's HCL configuration,
-// as well as the ResourceData provided by Terraform for context about the configuration, and returns
-// a single map representing the sym_flow's params. It will also make any necessary transformations to
-// ensure the params map is compatible with the Sym API.
-//
-// For example, it will remove any empty "strategy_id", since the API will reject it.
-func getAPISafeParams(paramsList []interface{}, data *schema.ResourceData) (map[string]interface{}, diag.Diagnostics) {
+// mergeUnmanagedParams adds the params recorded in `unmanaged_params` back into the params built by
+// flowParamsModel.apiParams, so that params this provider does not support are neither lost nor overwritten.
+// Params for prompt fields are only merged into the prompt field of the same name, if it still exists.
+func mergeUnmanagedParams(params map[string]interface{}, unmanagedParams map[string]string) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	// rawConfig gives us the actual values that were configured in Terraform.
-	// Since data.Get will give us the config with any defaults filled in, the rawConfig is
-	// the only way we can tell the difference between something like allowed_sources being
-	// an empty list or not configured at all.
-	rawConfig := data.GetRawConfig().AsValueMap()
-
-	// paramsList is only ever 0 or 1 items because that is the max we set in Terraform.
-	// length of 1 means that params were defined in Terraform.
-	if len(paramsList) == 1 {
-		// originalParamsMap will always contain the representation of Flow.params that
-		// Terraform accepts. This must be left alone for state to be saved properly.
-		originalParamsMap := paramsList[0].(map[string]interface{})
-
-		// ParamsMapCopy will contain the representation of Flow.params that the Sym API
-		// accepts. This will be modified to ensure the API receives the data it expects.
-		paramsMapCopy := map[string]interface{}{}
-		for k, v := range originalParamsMap {
-			// For allowed_sources, an explicit empty list means that the sym_flow is not invokable
-			// by any method, Slack or API. However, a nil value for allowed_sources means that we
-			// should let the Sym platform decide the default. So we need to check whether it was
-			// present in the raw Terraform config, and if it was not, DO NOT send it in the API request.
-			if k == "allowed_sources" {
-				// Based on the earlier len check, we already know that params exists, so
-				// we can skip a few safety checks and skip right to the types we want.
-				paramsMap := rawConfig["params"].AsValueSlice()[0].AsValueMap()
-				if allowedSources, ok := paramsMap["allowed_sources"]; ok && allowedSources.IsNull() {
-					continue
-				}
-			}
+	promptFields, _ := params["prompt_fields"].([]interface{})
 
-			paramsMapCopy[k] = v
+	for key, encoded := range unmanagedParams {
+		var value interface{}
+		if err := json.Unmarshal([]byte(encoded), &value); err != nil {
+			diags = append(diags, utils.DiagFromError(err, fmt.Sprintf("Unable to parse Flow unmanaged param %s", key)))
+			continue
 		}
 
-		// If strategy_id is an empty string, just omit it or the API will be unhappy.
-		if strategyId, found := paramsMapCopy["strategy_id"]; found && strategyId == "" {
-			delete(paramsMapCopy, "strategy_id")
+		if !strings.HasPrefix(key, "prompt_fields.") {
+			if _, found := params[key]; !found {
+				params[key] = value
+			}
+			continue
 		}
 
-		if originalPromptFields, found := paramsMapCopy["prompt_field"]; found {
-			// Because the Terraform block is called "prompt_field", it will be in params under that key.
-			// However, the API expects "prompt_fields", so change the key.
-			delete(paramsMapCopy, "prompt_field")
-
-			// Make new copies of each prompt field so the original is never modified. The original is what will be
-			// saved to the state, and we don't want to change that.
-			var promptFieldsCopy []interface{}
-			for i := range originalPromptFields.([]interface{}) {
-				originalPromptField := originalPromptFields.([]interface{})[i].(map[string]interface{})
-
-				// Warn users if they're using allowed_values for a prompt_field named "target_id", since it will
-				// be ignored by the Sym platform. We can't do this as part of a ValidateDiagFunc because it is
-				// a list field, which doesn't yet support ValidateDiagFunc. This means that the warning will appear
-				// after create/update, not during the plan step.
-				allowedValues := originalPromptField["allowed_values"]
-				if originalPromptField["name"] == "target_id" && len(allowedValues.([]interface{})) > 0 {
-					diags = append(diags, utils.DiagWarning(fmt.Sprintf("params.prompt_field.%v.allowed_values will be ignored", i), "prompt_fields named 'target_id' have auto-populated allowed_values, so the defined allowed_values will be ignored."))
-				}
-
-				// Construct a new prompt field.
-				promptFieldCopy := map[string]interface{}{}
-				for k, v := range originalPromptField {
-					if k == "on_change" {
-						// We want the state for on_change to stay as the human-readable version, but we should send the
-						// base64-encoded version to the Sym API.
-						promptFieldCopy[k] = base64.StdEncoding.EncodeToString([]byte(v.(string)))
-					} else {
-						promptFieldCopy[k] = v
-					}
-				}
-
-				promptFieldsCopy = append(promptFieldsCopy, promptFieldCopy)
+		for _, promptField := range promptFields {
+			field := promptField.(map[string]interface{})
+			prefix := unmanagedPromptFieldParamKey(field["name"], "")
+			if !strings.HasPrefix(key, prefix) {
+				continue
 			}
 
-			paramsMapCopy["prompt_fields"] = promptFieldsCopy
+			paramKey := strings.TrimPrefix(key, prefix)
+			if _, found := field[paramKey]; !found && !strings.Contains(paramKey, ".") {
+				field[paramKey] = value
+			}
 		}
-
-		return paramsMapCopy, diags
-	} else {
-		// If no params were defined, make sure we still send an empty params blob to the API.
-		return map[string]interface{}{}, diags
-	}
-}
-
-func validatePromptFieldType(typeName interface{}, _ cty.Path) diag.Diagnostics {
-	var results diag.Diagnostics
-
-	if !utils.ContainsString([]string{"string", "int", "bool", "duration", "slack_user", "slack_user_list", "str_list", "int_list"}, typeName.(string)) {
-		results = append(results, utils.DiagFromError(
-			fmt.Errorf(`"%v" is not a valid prompt_field type. Must be one of: "string", "int", "bool", "duration", "slack_user", "slack_user_list", "str_list", "int_list"`, typeName),
-			"Invalid prompt_field.type"),
-		)
 	}
 
-	return results
+	return diags
 }
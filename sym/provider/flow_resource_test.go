@@ -2,22 +2,35 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
 )
 
 func TestAccSymFlow_basic(t *testing.T) {
 	data := BuildTestData("basic-environment")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: createFlowConfig(data),
@@ -26,49 +39,49 @@ func TestAccSymFlow_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_flow.this", "label", "SSO Access2"),
 					resource.TestCheckResourceAttrSet("sym_flow.this", "implementation"),
 					resource.TestCheckResourceAttrPair("sym_flow.this", "environment_id", "sym_environment.this", "id"),
-					resource.TestCheckResourceAttrPair("sym_flow.this", "params.0.strategy_id", "sym_strategy.sso_main", "id"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allow_revoke", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allowed_sources.#", "2"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allowed_sources.0", "slack"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allowed_sources.1", "api"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.additional_header_text", "Additional Header Text"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allow_guest_interaction", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.include_decision_message", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.schedule_deescalation", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.#", "6"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.0.name", "reason"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.0.label", "Reason"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.0.type", "string"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.0.required", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.0.visible", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.1.name", "urgency"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.1.type", "string"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.1.required", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.1.visible", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.1.allowed_values.0", "Low"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.1.allowed_values.1", "Medium"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.1.allowed_values.2", "High"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.1.on_change", "file('before_on_change.py')"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.2.name", "slack_user"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.2.label", "Slack User"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.2.type", "slack_user"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.2.required", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.2.visible", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.3.name", "slack_user_list"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.3.label", "Slack User List"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.3.type", "slack_user_list"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.3.required", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.3.visible", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.4.name", "int_list"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.4.label", "Integer List"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.4.type", "int_list"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.4.required", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.4.visible", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.5.name", "str_list"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.5.label", "String List"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.5.type", "str_list"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.5.required", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.5.visible", "true"),
+					resource.TestCheckResourceAttrPair("sym_flow.this", "params.strategy_id", "sym_strategy.sso_main", "id"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allow_revoke", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allowed_sources.#", "2"),
+					resource.TestCheckTypeSetElemAttr("sym_flow.this", "params.allowed_sources.*", "slack"),
+					resource.TestCheckTypeSetElemAttr("sym_flow.this", "params.allowed_sources.*", "api"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.additional_header_text", "Additional Header Text"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allow_guest_interaction", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.include_decision_message", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.schedule_deescalation", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.#", "6"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.0.name", "reason"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.0.label", "Reason"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.0.type", "string"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.0.required", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.0.visible", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.1.name", "urgency"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.1.type", "string"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.1.required", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.1.visible", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.1.allowed_values.0", "Low"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.1.allowed_values.1", "Medium"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.1.allowed_values.2", "High"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.1.on_change", "file('before_on_change.py')"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.2.name", "slack_user"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.2.label", "Slack User"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.2.type", "slack_user"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.2.required", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.2.visible", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.3.name", "slack_user_list"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.3.label", "Slack User List"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.3.type", "slack_user_list"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.3.required", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.3.visible", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.4.name", "int_list"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.4.label", "Integer List"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.4.type", "int_list"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.4.required", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.4.visible", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.5.name", "str_list"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.5.label", "String List"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.5.type", "str_list"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.5.required", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.5.visible", "true"),
 				),
 			},
 			{
@@ -78,13 +91,13 @@ func TestAccSymFlow_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_flow.this", "label", "SSO Access2"),
 					resource.TestCheckResourceAttrSet("sym_flow.this", "implementation"),
 					resource.TestCheckResourceAttrPair("sym_flow.this", "environment_id", "sym_environment.this", "id"),
-					resource.TestCheckResourceAttrPair("sym_flow.this", "params.0.strategy_id", "sym_strategy.sso_main", "id"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allow_revoke", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.include_decision_message", "true"),
-					resource.TestCheckNoResourceAttr("sym_flow.this", "params.0.header_text"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allow_guest_interaction", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.schedule_deescalation", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.1.on_change", "file('after_on_change.py')"),
+					resource.TestCheckResourceAttrPair("sym_flow.this", "params.strategy_id", "sym_strategy.sso_main", "id"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allow_revoke", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.include_decision_message", "true"),
+					resource.TestCheckNoResourceAttr("sym_flow.this", "params.header_text"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allow_guest_interaction", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.schedule_deescalation", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.1.on_change", "file('after_on_change.py')"),
 				),
 			},
 		},
@@ -95,8 +108,8 @@ func TestAccSymFlow_nameCaseInsensitive(t *testing.T) {
 	data := BuildTestData("BASIC-ENVIRONMENT")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: createFlowConfig(data),
@@ -105,10 +118,10 @@ func TestAccSymFlow_nameCaseInsensitive(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_flow.this", "label", "SSO Access2"),
 					resource.TestCheckResourceAttrSet("sym_flow.this", "implementation"),
 					resource.TestCheckResourceAttrPair("sym_flow.this", "environment_id", "sym_environment.this", "id"),
-					resource.TestCheckResourceAttrPair("sym_flow.this", "params.0.strategy_id", "sym_strategy.sso_main", "id"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.include_decision_message", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allow_revoke", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.#", "6"),
+					resource.TestCheckResourceAttrPair("sym_flow.this", "params.strategy_id", "sym_strategy.sso_main", "id"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.include_decision_message", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allow_revoke", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.#", "6"),
 				),
 			},
 			{
@@ -118,10 +131,10 @@ func TestAccSymFlow_nameCaseInsensitive(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_flow.this", "label", "SSO Access2"),
 					resource.TestCheckResourceAttrSet("sym_flow.this", "implementation"),
 					resource.TestCheckResourceAttrPair("sym_flow.this", "environment_id", "sym_environment.this", "id"),
-					resource.TestCheckResourceAttrPair("sym_flow.this", "params.0.strategy_id", "sym_strategy.sso_main", "id"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.include_decision_message", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allow_revoke", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.#", "6"),
+					resource.TestCheckResourceAttrPair("sym_flow.this", "params.strategy_id", "sym_strategy.sso_main", "id"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.include_decision_message", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allow_revoke", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.#", "6"),
 				),
 			},
 		},
@@ -132,8 +145,8 @@ func TestAccSymFlow_noStrategy(t *testing.T) {
 	data := BuildTestData("basic-environment")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: createFlowNoStrategyConfig(data),
@@ -142,12 +155,12 @@ func TestAccSymFlow_noStrategy(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_flow.this", "label", "SSO Access2"),
 					resource.TestCheckResourceAttrSet("sym_flow.this", "implementation"),
 					resource.TestCheckResourceAttrPair("sym_flow.this", "environment_id", "sym_environment.this", "id"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.strategy_id", ""),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.include_decision_message", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allow_revoke", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.schedule_deescalation", "false"),
-					resource.TestCheckNoResourceAttr("sym_flow.this", "params.0.allowed_sources"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.#", "6"),
+					resource.TestCheckNoResourceAttr("sym_flow.this", "params.strategy_id"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.include_decision_message", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allow_revoke", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.schedule_deescalation", "false"),
+					resource.TestCheckNoResourceAttr("sym_flow.this", "params.allowed_sources"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.#", "6"),
 				),
 			},
 			{
@@ -157,11 +170,11 @@ func TestAccSymFlow_noStrategy(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_flow.this", "label", "SSO Access2"),
 					resource.TestCheckResourceAttrSet("sym_flow.this", "implementation"),
 					resource.TestCheckResourceAttrPair("sym_flow.this", "environment_id", "sym_environment.this", "id"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.strategy_id", ""),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.include_decision_message", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allow_revoke", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.schedule_deescalation", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.prompt_field.#", "6"),
+					resource.TestCheckNoResourceAttr("sym_flow.this", "params.strategy_id"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.include_decision_message", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allow_revoke", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.schedule_deescalation", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.prompt_field.#", "6"),
 				),
 			},
 		},
@@ -172,8 +185,8 @@ func TestAccSymFlow_allowedSourcesOnlyAPI(t *testing.T) {
 	data := BuildTestData("allowed-sources-slack-api")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: createFlowConfigWithOnlyAPISource(data),
@@ -182,11 +195,11 @@ func TestAccSymFlow_allowedSourcesOnlyAPI(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_flow.this", "label", "SSO Access2"),
 					resource.TestCheckResourceAttrSet("sym_flow.this", "implementation"),
 					resource.TestCheckResourceAttrPair("sym_flow.this", "environment_id", "sym_environment.this", "id"),
-					resource.TestCheckResourceAttrPair("sym_flow.this", "params.0.strategy_id", "sym_strategy.sso_main", "id"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.include_decision_message", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allow_revoke", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allowed_sources.#", "1"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allowed_sources.0", "api"),
+					resource.TestCheckResourceAttrPair("sym_flow.this", "params.strategy_id", "sym_strategy.sso_main", "id"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.include_decision_message", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allow_revoke", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allowed_sources.#", "1"),
+					resource.TestCheckTypeSetElemAttr("sym_flow.this", "params.allowed_sources.*", "api"),
 				),
 			},
 			{
@@ -196,10 +209,10 @@ func TestAccSymFlow_allowedSourcesOnlyAPI(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_flow.this", "label", "SSO Access2"),
 					resource.TestCheckResourceAttrSet("sym_flow.this", "implementation"),
 					resource.TestCheckResourceAttrPair("sym_flow.this", "environment_id", "sym_environment.this", "id"),
-					resource.TestCheckResourceAttrPair("sym_flow.this", "params.0.strategy_id", "sym_strategy.sso_main", "id"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.include_decision_message", "true"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allow_revoke", "false"),
-					resource.TestCheckResourceAttr("sym_flow.this", "params.0.allowed_sources.#", "0"),
+					resource.TestCheckResourceAttrPair("sym_flow.this", "params.strategy_id", "sym_strategy.sso_main", "id"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.include_decision_message", "true"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allow_revoke", "false"),
+					resource.TestCheckResourceAttr("sym_flow.this", "params.allowed_sources.#", "0"),
 				),
 			},
 		},
@@ -383,14 +396,16 @@ func createFlowConfigWithOnlyAPISource(data TestData) string {
 	return flowConfig(data, "internal/testdata/after_impl.py", true, false, "sym_strategy.sso_main.id", true, `["api"]`, "", false, "before_on_change.py")
 }
 
-//// Test the state upgrade from provider < 2.0.0 to 2.0.0 ////////////////////
+//// Test the state upgrades of sym_flow //////////////////////////////////////
+
+const testFlowImplementation = "from sym.sdk.annotations import reducer\nfrom sym.sdk.integrations import slack\n\n\n@reducer\ndef get_approvers(request):\n    return slack.channel(\"#access-requests\")\n"
 
 // testFlowResourceStateUpgradeDataV0 represents an example of pre-2.0 state
 func testFlowResourceStateUpgradeDataV0() map[string]interface{} {
 	return map[string]interface{}{
 		"environment_id": "60c49c8c-f181-41e4-8696-99cc0b0ffc4f",
 		"id":             "34ba01e4-fda8-4c57-bc48-9c4a3483d3fb",
-		"implementation": "from sym.sdk.annotations import reducer\nfrom sym.sdk.integrations import slack\n\n\n@reducer\ndef get_approvers(request):\n    return slack.channel(\"#access-requests\")\n",
+		"implementation": testFlowImplementation,
 		"label":          "V2 Provider Test",
 		"name":           "flow-v2tftake2",
 		"params": map[string]interface{}{
@@ -406,55 +421,314 @@ func testFlowResourceStateUpgradeDataV0() map[string]interface{} {
 	}
 }
 
-// testFlowResourceStateUpgradeDataV1 represents an example of post-2.0 state
+// testFlowResourceStateUpgradeDataV1 represents an example of state written by the SDK provider
 func testFlowResourceStateUpgradeDataV1() map[string]interface{} {
 	return map[string]interface{}{
 		"environment_id": "60c49c8c-f181-41e4-8696-99cc0b0ffc4f",
 		"id":             "34ba01e4-fda8-4c57-bc48-9c4a3483d3fb",
-		"implementation": "from sym.sdk.annotations import reducer\nfrom sym.sdk.integrations import slack\n\n\n@reducer\ndef get_approvers(request):\n    return slack.channel(\"#access-requests\")\n",
+		"org":            "test-org",
+		"full_name":      "flow-v2tftake2",
+		"implementation": testFlowImplementation,
 		"label":          "V2 Provider Test",
 		"name":           "flow-v2tftake2",
 		"params": []interface{}{
 			map[string]interface{}{
-				"allow_guest_interaction":  "true",
-				"allow_revoke":             "true",
-				"include_decision_message": "true",
+				"strategy_id":              "",
+				"additional_header_text":   "",
+				"allow_guest_interaction":  true,
+				"allow_revoke":             true,
+				"include_decision_message": true,
 				"allowed_sources":          []string{"api", "slack"},
 				"prompt_field": []interface{}{
 					map[string]interface{}{
-						"name":     "reason",
-						"type":     "string",
-						"required": true,
-						"label":    "Reason",
-						"visible":  true,
+						"name":           "reason",
+						"type":           "string",
+						"required":       true,
+						"label":          "Reason",
+						"default":        "",
+						"visible":        true,
+						"allowed_values": []string{},
+						"prefetch":       false,
+						"on_change":      "",
 					},
 					map[string]interface{}{
 						"name":           "urgency",
 						"type":           "string",
 						"required":       true,
+						"label":          "",
+						"default":        "",
 						"visible":        true,
 						"allowed_values": []string{"Low", "Medium", "High"},
+						"prefetch":       false,
+						"on_change":      "",
 					},
 				},
-				"schedule_deescalation": "true",
+				"schedule_deescalation": true,
 			},
 		},
-		"vars": map[string]string{},
+		"unmanaged_params":    map[string]string{"request_expiration": `{"hours":4}`},
+		"deletion_protection": true,
+		"updated_at":          "2024-01-01T00:00:00Z",
+		"updated_by":          "user@symops.io",
+		"etag":                "abc123",
+		"vars":                map[string]string{},
 	}
 }
 
+// testUpgradedFlowParams returns the params of both example states, once they have been upgraded.
+func testUpgradedFlowParams() *flowParamsModel {
+	return &flowParamsModel{
+		StrategyId:             types.StringNull(),
+		AllowRevoke:            types.BoolValue(true),
+		IncludeDecisionMessage: types.BoolValue(true),
+		AllowedSources:         setParam([]string{"api", "slack"}),
+		ScheduleDeescalation:   types.BoolValue(true),
+		PromptFields: []promptFieldModel{
+			{
+				Name:          types.StringValue("reason"),
+				Type:          types.StringValue("string"),
+				Required:      types.BoolValue(true),
+				Label:         types.StringValue("Reason"),
+				Default:       types.StringNull(),
+				Visible:       types.BoolValue(true),
+				AllowedValues: types.ListNull(types.StringType),
+				Prefetch:      types.BoolValue(false),
+				OnChange:      newImplementationNull(),
+			},
+			{
+				Name:          types.StringValue("urgency"),
+				Type:          types.StringValue("string"),
+				Required:      types.BoolValue(true),
+				Label:         types.StringNull(),
+				Default:       types.StringNull(),
+				Visible:       types.BoolValue(true),
+				AllowedValues: listParam([]string{"Low", "Medium", "High"}),
+				Prefetch:      types.BoolValue(false),
+				OnChange:      newImplementationNull(),
+			},
+		},
+		AdditionalHeaderText:  types.StringNull(),
+		AllowGuestInteraction: types.BoolValue(true),
+	}
+}
+
+// upgradeFlowState upgrades the given state of sym_flow through the framework provider's server, as Terraform would.
+func upgradeFlowState(t *testing.T, version int64, state map[string]interface{}) flowModel {
+	ctx := context.Background()
+
+	rawState, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	server := providerserver.NewProtocol5(newFrameworkProvider())()
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: "sym_flow",
+		Version:  version,
+		RawState: &tfprotov5.RawState{JSON: rawState},
+	})
+	require.NoError(t, err)
+	for _, d := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	require.NotNil(t, resp.UpgradedState)
+
+	s := flowSchema()
+	raw, err := resp.UpgradedState.Unmarshal(s.Type().TerraformType(ctx))
+	require.NoError(t, err)
+
+	var upgraded flowModel
+	diags := tfsdk.State{Raw: raw, Schema: s}.Get(ctx, &upgraded)
+	require.False(t, diags.HasError(), "decoding upgraded state: %v", diags)
+	return upgraded
+}
+
 func TestFlowResourceStateUpgradeV0(t *testing.T) {
-	expected := testFlowResourceStateUpgradeDataV1()
+	implementation := newImplementationValue(testFlowImplementation)
+	expected := flowModel{
+		Id:                 types.StringValue("34ba01e4-fda8-4c57-bc48-9c4a3483d3fb"),
+		Org:                types.StringNull(),
+		Name:               types.StringValue("flow-v2tftake2"),
+		FullName:           types.StringNull(),
+		Label:              types.StringValue("V2 Provider Test"),
+		Implementation:     implementation,
+		ImplementationWO:   types.StringNull(),
+		ImplementationHash: implementationHash(implementation.StringValue),
+		Vars:               types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		EnvironmentId:      types.StringValue("60c49c8c-f181-41e4-8696-99cc0b0ffc4f"),
+		Params:             testUpgradedFlowParams(),
+		UnmanagedParams:    types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		DeletionProtection: types.BoolValue(false),
+		metadataModel: metadataModel{
+			UpdatedAt: types.StringNull(),
+			UpdatedBy: types.StringNull(),
+			ETag:      types.StringNull(),
+		},
+	}
 
-	// "Code should use context.TODO when it's unclear which Context to use or it is not yet available"
-	// https://pkg.go.dev/context#TODO
-	actual, err := flowResourceStateUpgradeV0(context.TODO(), testFlowResourceStateUpgradeDataV0(), nil)
-	if err != nil {
-		t.Fatalf("error migrating state: %s", err)
+	assert.Equal(t, expected, upgradeFlowState(t, 0, testFlowResourceStateUpgradeDataV0()))
+}
+
+func TestFlowResourceStateUpgradeV1(t *testing.T) {
+	implementation := newImplementationValue(testFlowImplementation)
+	expected := flowModel{
+		Id:                 types.StringValue("34ba01e4-fda8-4c57-bc48-9c4a3483d3fb"),
+		Org:                types.StringValue("test-org"),
+		Name:               types.StringValue("flow-v2tftake2"),
+		FullName:           types.StringValue("flow-v2tftake2"),
+		Label:              types.StringValue("V2 Provider Test"),
+		Implementation:     implementation,
+		ImplementationWO:   types.StringNull(),
+		ImplementationHash: implementationHash(implementation.StringValue),
+		Vars:               types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		EnvironmentId:      types.StringValue("60c49c8c-f181-41e4-8696-99cc0b0ffc4f"),
+		Params:             testUpgradedFlowParams(),
+		UnmanagedParams: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"request_expiration": types.StringValue(`{"hours":4}`),
+		}),
+		DeletionProtection: types.BoolValue(true),
+		metadataModel: metadataModel{
+			UpdatedAt: types.StringValue("2024-01-01T00:00:00Z"),
+			UpdatedBy: types.StringValue("user@symops.io"),
+			ETag:      types.StringValue("abc123"),
+		},
 	}
 
-	if !reflect.DeepEqual(expected, actual) {
-		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
+	assert.Equal(t, expected, upgradeFlowState(t, 1, testFlowResourceStateUpgradeDataV1()))
+}
+
+func TestFlowResourceStateUpgradeV1_hashedImplementation(t *testing.T) {
+	hashed := utils.HashImpl(testFlowImplementation)
+	state := testFlowResourceStateUpgradeDataV1()
+	state["implementation"] = hashed
+
+	upgraded := upgradeFlowState(t, 1, state)
+	assert.Equal(t, types.StringValue(hashed), upgraded.ImplementationHash)
+	assert.Equal(t, newImplementationValue(hashed), upgraded.Implementation)
+}
+
+func TestFlowResourceStateUpgradeV2(t *testing.T) {
+	state := testFlowResourceStateUpgradeDataV1()
+	implementation := newImplementationValue(testFlowImplementation)
+	state["implementation_wo"] = nil
+	state["implementation_hash"] = implementationHash(implementation.StringValue).ValueString()
+
+	params := state["params"].([]interface{})[0].(map[string]interface{})
+	params["strategy_id"] = nil
+	params["additional_header_text"] = nil
+	reason := params["prompt_field"].([]interface{})[0].(map[string]interface{})
+	urgency := params["prompt_field"].([]interface{})[1].(map[string]interface{})
+	for _, field := range []map[string]interface{}{reason, urgency} {
+		field["default"] = nil
+		field["on_change"] = nil
+	}
+	reason["allowed_values"] = nil
+	urgency["label"] = nil
+	state["params"] = params
+
+	// Version 2 is the state that version 1 was upgraded to, with `params.allowed_sources` stored as a list.
+	assert.Equal(t, upgradeFlowState(t, 1, testFlowResourceStateUpgradeDataV1()), upgradeFlowState(t, 2, state))
+}
+
+func Test_readFlowParams(t *testing.T) {
+	params := map[string]interface{}{
+		"strategy_id":        "",
+		"allow_revoke":       false,
+		"allowed_sources":    []interface{}{"slack"},
+		"request_expiration": map[string]interface{}{"hours": float64(4)},
+		"prompt_fields": []interface{}{
+			map[string]interface{}{"name": "reason", "type": "string", "placeholder": "Why?"},
+		},
+	}
+
+	model, unmanaged, diags := readFlowParams(params, nil)
+	require.False(t, diags.HasError(), "readFlowParams() returned errors: %v", diags)
+
+	assert.Equal(t, map[string]string{
+		"request_expiration":               `{"hours":4}`,
+		"prompt_fields.reason.placeholder": `"Why?"`,
+	}, unmanaged)
+	assert.Equal(t, types.StringNull(), model.StrategyId)
+	assert.Equal(t, types.BoolValue(false), model.AllowRevoke)
+	assert.Equal(t, types.BoolValue(false), model.IncludeDecisionMessage)
+	assert.Equal(t, setParam([]string{"slack"}), model.AllowedSources)
+	require.Len(t, model.PromptFields, 1)
+	assert.Equal(t, types.StringNull(), model.PromptFields[0].Label)
+	assert.Equal(t, types.BoolValue(true), model.PromptFields[0].Visible)
+
+	// An empty strategy_id stays empty if it was configured that way.
+	model, _, diags = readFlowParams(params, &flowParamsModel{StrategyId: types.StringValue("")})
+	require.False(t, diags.HasError(), "readFlowParams() returned errors: %v", diags)
+	assert.Equal(t, types.StringValue(""), model.StrategyId)
+
+	// Params are left unset when Sym returns none of the params managed by sym_flow.
+	model, unmanaged, diags = readFlowParams(map[string]interface{}{"new_flag": true}, nil)
+	require.False(t, diags.HasError(), "readFlowParams() returned errors: %v", diags)
+	assert.Nil(t, model)
+	assert.Equal(t, map[string]string{"new_flag": "true"}, unmanaged)
+}
+
+func Test_flowParamsModel_apiParams(t *testing.T) {
+	ctx := context.Background()
+
+	var unset *flowParamsModel
+	params, diags := unset.apiParams(ctx)
+	require.False(t, diags.HasError(), "apiParams() returned errors: %v", diags)
+	assert.Equal(t, map[string]interface{}{}, params)
+
+	model := testUpgradedFlowParams()
+	model.PromptFields[1].OnChange = newImplementationValue("print('hi')")
+	params, diags = model.apiParams(ctx)
+	require.False(t, diags.HasError(), "apiParams() returned errors: %v", diags)
+
+	assert.NotContains(t, params, "strategy_id")
+	assert.Equal(t, true, params["allow_revoke"])
+	assert.Equal(t, []interface{}{"api", "slack"}, params["allowed_sources"])
+	assert.Equal(t, "", params["additional_header_text"])
+
+	promptFields, ok := params["prompt_fields"].([]interface{})
+	require.True(t, ok, "prompt_fields = %v", params["prompt_fields"])
+	require.Len(t, promptFields, 2)
+	urgency := promptFields[1].(map[string]interface{})
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("print('hi')")), urgency["on_change"])
+	assert.Equal(t, []interface{}{"Low", "Medium", "High"}, urgency["allowed_values"])
+}
+
+func Test_planImplementationState(t *testing.T) {
+	ctx := context.Background()
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"implementation": schema.StringAttribute{CustomType: implementationType{}, Optional: true},
+		},
+	}
+	value := func(implementation interface{}) tftypes.Value {
+		return tftypes.NewValue(s.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"implementation": tftypes.NewValue(tftypes.String, implementation),
+		})
+	}
+	hashed := utils.HashImpl(testFlowImplementation)
+
+	tests := []struct {
+		name                string
+		hashImplementations bool
+		configured          interface{}
+		planned             interface{}
+		want                interface{}
+	}{
+		{"hashed", true, testFlowImplementation, testFlowImplementation, hashed},
+		{"already-hashed", true, testFlowImplementation, hashed, hashed},
+		{"plaintext", false, testFlowImplementation, testFlowImplementation, testFlowImplementation},
+		{"no-longer-hashed", false, testFlowImplementation, hashed, testFlowImplementation},
+		{"unknown", true, tftypes.UnknownValue, tftypes.UnknownValue, tftypes.UnknownValue},
+		{"null", true, nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := tfsdk.Plan{Raw: value(tt.planned), Schema: s}
+			diags := planImplementationState(ctx, &providerMeta{HashImplementations: tt.hashImplementations},
+				tfsdk.Config{Raw: value(tt.configured), Schema: s}, &plan, path.Root("implementation"))
+			require.False(t, diags.HasError(), "planImplementationState() returned errors: %v", diags)
+			assert.True(t, plan.Raw.Equal(value(tt.want)), "planned %v, want %v", plan.Raw, tt.want)
+		})
 	}
 }
 
@@ -540,3 +814,68 @@ func Test_checkFlowVars(t *testing.T) {
 		})
 	}
 }
+
+func Test_mergeUnmanagedParams(t *testing.T) {
+	tests := []struct {
+		name            string
+		params          map[string]interface{}
+		unmanagedParams map[string]string
+		want            map[string]interface{}
+	}{
+		{
+			"no-unmanaged-params",
+			map[string]interface{}{"allow_revoke": true},
+			map[string]string{},
+			map[string]interface{}{"allow_revoke": true},
+		},
+		{
+			"top-level-params",
+			map[string]interface{}{"allow_revoke": true},
+			map[string]string{
+				"request_expiration": `{"hours":4}`,
+				"new_flag":           "true",
+			},
+			map[string]interface{}{
+				"allow_revoke":       true,
+				"request_expiration": map[string]interface{}{"hours": float64(4)},
+				"new_flag":           true,
+			},
+		},
+		{
+			"managed-params-are-not-overwritten",
+			map[string]interface{}{"allow_revoke": true},
+			map[string]string{"allow_revoke": "false"},
+			map[string]interface{}{"allow_revoke": true},
+		},
+		{
+			"prompt-field-params",
+			map[string]interface{}{
+				"prompt_fields": []interface{}{
+					map[string]interface{}{"name": "reason", "type": "string"},
+					map[string]interface{}{"name": "duration", "type": "duration"},
+				},
+			},
+			map[string]string{
+				"prompt_fields.reason.placeholder": `"Why do you need access?"`,
+				"prompt_fields.reason.type":        `"int"`,
+				"prompt_fields.removed.min_length": "10",
+			},
+			map[string]interface{}{
+				"prompt_fields": []interface{}{
+					map[string]interface{}{"name": "reason", "type": "string", "placeholder": "Why do you need access?"},
+					map[string]interface{}{"name": "duration", "type": "duration"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diags := mergeUnmanagedParams(tt.params, tt.unmanagedParams); diags.HasError() {
+				t.Fatalf("mergeUnmanagedParams() returned errors: %v", diags)
+			}
+			if !reflect.DeepEqual(tt.params, tt.want) {
+				t.Errorf("mergeUnmanagedParams() = %v, want %v", tt.params, tt.want)
+			}
+		})
+	}
+}
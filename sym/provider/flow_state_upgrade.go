@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+// UpgradeState migrates users' state from the schemas of earlier provider versions. The upgraders from versions 0
+// and 1 leave unset values null, as the framework distinguishes them from the empty values stored by the SDK.
+func (r *symFlowResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {PriorSchema: flowSchemaV0(), StateUpgrader: upgradeFlowStateV0},
+		1: {PriorSchema: flowSchemaV1(), StateUpgrader: upgradeFlowStateV1},
+		2: {StateUpgrader: upgradeFlowStateV2},
+	}
+}
+
+// flowSchemaV0 returns the Terraform schema for sym_flow for the provider version < 2.0.0.
+func flowSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":             schema.StringAttribute{Computed: true},
+			"name":           schema.StringAttribute{Required: true},
+			"label":          schema.StringAttribute{Optional: true},
+			"template":       schema.StringAttribute{Required: true},
+			"implementation": schema.StringAttribute{Required: true},
+			"vars":           schema.MapAttribute{ElementType: types.StringType, Optional: true},
+			"environment_id": schema.StringAttribute{Required: true},
+			"params":         schema.MapAttribute{ElementType: types.StringType, Required: true},
+		},
+	}
+}
+
+// flowModelV0 is the state of a sym_flow for the provider version < 2.0.0, when every param was stored as a string.
+type flowModelV0 struct {
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Label          types.String `tfsdk:"label"`
+	Template       types.String `tfsdk:"template"`
+	Implementation types.String `tfsdk:"implementation"`
+	Vars           types.Map    `tfsdk:"vars"`
+	EnvironmentId  types.String `tfsdk:"environment_id"`
+	Params         types.Map    `tfsdk:"params"`
+}
+
+// upgradeFlowStateV0 migrates users' state from Terraform Provider < 2.0.0. `template` is no longer part of
+// sym_flow, and the params are converted as if they were read from Sym.
+func upgradeFlowStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior flowModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	priorParams := map[string]string{}
+	if !prior.Params.IsNull() {
+		resp.Diagnostics.Append(prior.Params.ElementsAs(ctx, &priorParams, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := map[string]interface{}{}
+	for key, value := range priorParams {
+		switch key {
+		case "allowed_sources_json":
+			// Ignore any json unmarshalling error, as the Sym API will report invalid params on the next update.
+			var allowedSources []string
+			_ = json.Unmarshal([]byte(value), &allowedSources)
+			params["allowed_sources"] = allowedSources
+		case "prompt_fields_json":
+			var promptFields []interface{}
+			_ = json.Unmarshal([]byte(value), &promptFields)
+			params["prompt_fields"] = promptFields
+		default:
+			if _, isBool := flowParamDefaults[key]; isBool {
+				if b, err := strconv.ParseBool(value); err == nil {
+					params[key] = b
+					continue
+				}
+			}
+			params[key] = value
+		}
+	}
+
+	flowParams, unmanagedParams, diags := readFlowParams(params, nil)
+	resp.Diagnostics.Append(diags...)
+	unmanaged, diags := types.MapValueFrom(ctx, types.StringType, unmanagedParams)
+	resp.Diagnostics.Append(diags...)
+
+	implementation := newImplementationValue(prior.Implementation.ValueString())
+	state := flowModel{
+		Id:                 prior.Id,
+		Org:                types.StringNull(),
+		Name:               prior.Name,
+		FullName:           types.StringNull(),
+		Label:              unsetString(prior.Label.ValueString(), types.StringNull()),
+		Implementation:     implementation,
+		ImplementationWO:   types.StringNull(),
+		ImplementationHash: implementationHash(implementation.StringValue),
+		Vars:               upgradedVars(prior.Vars),
+		EnvironmentId:      prior.EnvironmentId,
+		Params:             flowParams,
+		UnmanagedParams:    unmanaged,
+		DeletionProtection: types.BoolValue(false),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// flowSchemaV1 returns the schema of sym_flow when it was served by the SDK provider, with the `params` block
+// stored as a list of at most one element. `allowed_sources` is read as a set, which is encoded in the state
+// like the list it was then.
+func flowSchemaV1() *schema.Schema {
+	s := flowSchema()
+	s.Version = 1
+	delete(s.Attributes, "implementation_wo")
+	delete(s.Attributes, "implementation_hash")
+
+	params := s.Blocks["params"].(schema.SingleNestedBlock)
+	s.Blocks["params"] = schema.ListNestedBlock{
+		NestedObject: schema.NestedBlockObject{
+			Attributes: params.Attributes,
+			Blocks:     params.Blocks,
+		},
+	}
+	return &s
+}
+
+// flowModelV1 is the state of a sym_flow when it was served by the SDK provider.
+type flowModelV1 struct {
+	Id                 types.String        `tfsdk:"id"`
+	Org                types.String        `tfsdk:"org"`
+	Name               types.String        `tfsdk:"name"`
+	FullName           types.String        `tfsdk:"full_name"`
+	Label              types.String        `tfsdk:"label"`
+	Implementation     implementationValue `tfsdk:"implementation"`
+	Vars               types.Map           `tfsdk:"vars"`
+	EnvironmentId      types.String        `tfsdk:"environment_id"`
+	Params             []flowParamsModel   `tfsdk:"params"`
+	UnmanagedParams    types.Map           `tfsdk:"unmanaged_params"`
+	DeletionProtection types.Bool          `tfsdk:"deletion_protection"`
+	metadataModel
+}
+
+// upgradeFlowStateV1 migrates users' state from the SDK provider. The SDK stored unset strings and lists as empty
+// values, which are now null, and the implementation may be a hash if `hash_implementations` was enabled.
+// Either way, the next refresh replaces it with the implementation read from Sym, hashed if the setting is still enabled.
+func upgradeFlowStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior flowModelV1
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash := implementationHash(prior.Implementation.StringValue)
+	if utils.IsHashedValue(prior.Implementation.ValueString()) {
+		hash = prior.Implementation.StringValue
+	}
+
+	state := flowModel{
+		Id:                 prior.Id,
+		Org:                prior.Org,
+		Name:               prior.Name,
+		FullName:           prior.FullName,
+		Label:              unsetString(prior.Label.ValueString(), types.StringNull()),
+		Implementation:     prior.Implementation,
+		ImplementationWO:   types.StringNull(),
+		ImplementationHash: hash,
+		Vars:               upgradedVars(prior.Vars),
+		EnvironmentId:      prior.EnvironmentId,
+		UnmanagedParams:    prior.UnmanagedParams,
+		DeletionProtection: types.BoolValue(prior.DeletionProtection.ValueBool()),
+		metadataModel:      prior.metadataModel,
+	}
+	if state.UnmanagedParams.IsNull() {
+		state.UnmanagedParams = types.MapValueMust(types.StringType, nil)
+	}
+
+	if len(prior.Params) > 0 {
+		params := prior.Params[0]
+		params.StrategyId = unsetString(params.StrategyId.ValueString(), types.StringNull())
+		params.AdditionalHeaderText = unsetString(params.AdditionalHeaderText.ValueString(), types.StringNull())
+		params.AllowedSources = unsetSet(params.AllowedSources)
+		if params.PromptFields == nil {
+			params.PromptFields = []promptFieldModel{}
+		}
+		for i, field := range params.PromptFields {
+			field.Label = unsetString(field.Label.ValueString(), types.StringNull())
+			field.Default = unsetString(field.Default.ValueString(), types.StringNull())
+			field.AllowedValues = unsetList(field.AllowedValues)
+			if field.OnChange.ValueString() == "" {
+				field.OnChange = newImplementationNull()
+			}
+			params.PromptFields[i] = field
+		}
+		state.Params = &params
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// upgradedVars returns the `vars` of an upgraded state, which default to an empty map.
+func upgradedVars(vars types.Map) types.Map {
+	if vars.IsNull() {
+		return types.MapValueMust(types.StringType, nil)
+	}
+	return vars
+}
+
+// upgradeFlowStateV2 migrates users' state from before `params.allowed_sources` became a set. A list and a set
+// are encoded alike in the state, so it is unchanged.
+func upgradeFlowStateV2(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: req.RawState.JSON}
+}
+
+// unsetSet returns null for an empty set, which the SDK stored for unset lists.
+func unsetSet(s types.Set) types.Set {
+	if len(s.Elements()) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	return s
+}
+
+// unsetList returns null for an empty list, which the SDK stored for unset lists.
+func unsetList(l types.List) types.List {
+	if len(l.Elements()) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	return l
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/base64"
 	"fmt"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/symopsio/terraform-provider-sym/sym/client"
@@ -23,41 +25,209 @@ func FlowsFilter() *schema.Resource {
 		ReadContext:   readFlowsFilter,
 		UpdateContext: updateFlowsFilter,
 		DeleteContext: deleteFlowsFilter,
+		CustomizeDiff: customdiff.All(
+			customizeDiffFlowsFilterRemoteOwner,
+			validateOnPlan("FlowsFilter", flowsFilterPatchFields, validateFlowsFilterOnPlan),
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: getSlugImporter("flows_filter"),
+			StateContext: importFlowsFilter,
 		},
 		Schema: map[string]*schema.Schema{
 			"implementation": {
 				Type:             schema.TypeString,
 				Required:         true,
 				ValidateDiagFunc: ImplementationValidation,
+				DiffSuppressFunc: utils.SuppressEquivalentImplDiffs,
+				StateFunc:        utils.NormalizeImplStateFunc,
 				Description:      "Python code defining the `get_flows` reducer for the FlowsFilter.",
 			},
-			"vars":         utils.SettingsMap("A map of variables and their values to pass to this FlowsFilter implementation."),
-			"integrations": utils.SettingsMap("A map of Integrations available when executing this FlowsFilter's implementation."),
+			"vars":                utils.SettingsMap("A map of variables and their values to pass to this FlowsFilter implementation."),
+			"integrations":        utils.SettingsMap("A map of Integrations available when executing this FlowsFilter's implementation."),
+			"deletion_protection": utils.DeletionProtection(),
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true and a FlowsFilter already exists in the org when this resource is created, the existing FlowsFilter is updated in place and its prior configuration is recorded in `prior`, instead of failing.",
+			},
+			"on_destroy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          flowsFilterOnDestroyDelete,
+				ValidateDiagFunc: validateFlowsFilterOnDestroy,
+				Description:      `What to do with the FlowsFilter when this resource is destroyed. One of: "delete", which deletes the FlowsFilter, or "restore", which restores the configuration recorded in ` + "`prior`" + ` when the FlowsFilter was adopted. If nothing was adopted, "restore" deletes the FlowsFilter.`,
+			},
+			"owner": utils.Optional(schema.TypeString,
+				"An identifier for the Terraform configuration that manages this FlowsFilter, e.g. `terraform.workspace`. "+
+					"If set, any sym_flows_filter with a different owner will refuse to adopt, modify, or delete the FlowsFilter.",
+			),
+			"remote_owner": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The owner of the FlowsFilter in Sym, which differs from `owner` if another sym_flows_filter has since taken it over.",
+			},
+			"prior": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The configuration of the FlowsFilter that existed before it was adopted by this resource. The implementation is stored base64-encoded in the Terraform state so that it can be restored.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"implementation": {Type: schema.TypeString, Computed: true, Description: "The base64-encoded implementation of the adopted FlowsFilter."},
+						"vars":           {Type: schema.TypeMap, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}, Description: "The vars of the adopted FlowsFilter."},
+						"integrations":   {Type: schema.TypeMap, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}, Description: "The integrations of the adopted FlowsFilter."},
+						"owner":          {Type: schema.TypeString, Computed: true, Description: "The owner of the adopted FlowsFilter."},
+					},
+				},
+			},
 		},
 	}
 }
 
-// Create a flowsFilter using the HTTP client
-func createFlowsFilter(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+const (
+	flowsFilterOnDestroyDelete  = "delete"
+	flowsFilterOnDestroyRestore = "restore"
+)
+
+func validateFlowsFilterOnDestroy(value interface{}, _ cty.Path) diag.Diagnostics {
+	var results diag.Diagnostics
+
+	if !utils.ContainsString([]string{flowsFilterOnDestroyDelete, flowsFilterOnDestroyRestore}, value.(string)) {
+		results = append(results, utils.DiagFromError(
+			fmt.Errorf(`"%v" is not a valid on_destroy value. Must be one of: "delete", "restore"`, value),
+			"Invalid on_destroy"),
+		)
+	}
+
+	return results
+}
 
+// customizeDiffFlowsFilterRemoteOwner plans `remote_owner` to become the configured owner when the owner changes,
+// since the new owner is sent to Sym by the update.
+func customizeDiffFlowsFilterRemoteOwner(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("owner") {
+		return nil
+	}
+	if !d.NewValueKnown("owner") {
+		return d.SetNewComputed("remote_owner")
+	}
+	return d.SetNew("remote_owner", d.Get("owner"))
+}
+
+// checkFlowsFilterOwner returns an error if the FlowsFilter is owned by a different sym_flows_filter
+// than the one with the given owner. A FlowsFilter without an owner may be managed by anyone.
+func checkFlowsFilterOwner(flowsFilter *client.FlowsFilter, owner string) error {
+	if flowsFilter.Owner != "" && flowsFilter.Owner != owner {
+		return utils.ErrFlowsFilterOwned(flowsFilter.Owner, owner)
+	}
+	return nil
+}
+
+// priorFlowsFilter returns the value of the `prior` attribute recording the given FlowsFilter.
+func priorFlowsFilter(flowsFilter *client.FlowsFilter) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"implementation": flowsFilter.Implementation,
+		"vars":           flowsFilter.Vars,
+		"integrations":   flowsFilter.Integrations,
+		"owner":          flowsFilter.Owner,
+	}}
+}
+
+// getPriorFlowsFilter returns the FlowsFilter recorded in the `prior` attribute, or nil if none was recorded.
+func getPriorFlowsFilter(data *schema.ResourceData) *client.FlowsFilter {
+	if data.Get("prior.#").(int) == 0 {
+		return nil
+	}
+
+	return &client.FlowsFilter{
+		Implementation: data.Get("prior.0.implementation").(string),
+		Vars:           getSettingsMap(data, "prior.0.vars"),
+		Integrations:   getSettingsMap(data, "prior.0.integrations"),
+		Owner:          data.Get("prior.0.owner").(string),
+	}
+}
+
+// importFlowsFilter is the ResourceImporter for sym_flows_filter. There is only one FlowsFilter in an org, so
+// any identifier may be used to import it, and it is resolved to the FlowsFilter's ID.
+func importFlowsFilter(_ context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	flowsFilter, err := meta.(*providerMeta).Client.FlowsFilter.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	data.SetId(flowsFilter.Id)
+	if err := data.Set("owner", flowsFilter.Owner); err != nil {
+		return nil, err
+	}
+	if err := data.Set("adopt_existing", false); err != nil {
+		return nil, err
+	}
+	if err := data.Set("on_destroy", flowsFilterOnDestroyDelete); err != nil {
+		return nil, err
+	}
+	return importedResourceData(data)
+}
+
+// buildFlowsFilter returns the FlowsFilter configured by the resource.
+func buildFlowsFilter(data resourceGetter) client.FlowsFilter {
 	flowsFilter := client.FlowsFilter{
 		Vars:         getSettingsMap(data, "vars"),
 		Integrations: getSettingsMap(data, "integrations"),
+		Owner:        data.Get("owner").(string),
 	}
 
 	// base64 encode the implementation
-	implementation := data.Get("implementation").(string)
+	implementation := getImplementation(data, "implementation")
 	flowsFilter.Implementation = base64.StdEncoding.EncodeToString([]byte(implementation))
 
+	return flowsFilter
+}
+
+func validateFlowsFilterOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	return m.Client.FlowsFilter.Validate(buildFlowsFilter(d))
+}
+
+// Create a flowsFilter using the HTTP client
+func createFlowsFilter(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m := meta.(*providerMeta)
+	c := m.Client
+
+	flowsFilter := buildFlowsFilter(data)
+	implementation := getImplementation(data, "implementation")
+
+	// There can only be one FlowsFilter per org, so check whether one already exists before creating it.
+	existing, err := c.FlowsFilter.Read()
+	if err != nil && !utils.IsNotFoundError(err) {
+		return utils.DiagsFromError(err, "Unable to create FlowsFilter")
+	}
+
+	if existing != nil {
+		if !data.Get("adopt_existing").(bool) {
+			return utils.DiagsFromError(utils.ErrFlowsFilterExists, "Unable to create FlowsFilter")
+		}
+		if err := checkFlowsFilterOwner(existing, flowsFilter.Owner); err != nil {
+			return utils.DiagsFromError(err, "Unable to adopt FlowsFilter")
+		}
+
+		// Only adopt the FlowsFilter that was checked above, in case it is changed concurrently.
+		if _, err := c.WithETag(existing.ETag).FlowsFilter.Update(flowsFilter); err != nil {
+			return utils.DiagsFromError(err, "Unable to adopt FlowsFilter")
+		}
+
+		data.SetId(existing.Id)
+		diags = utils.DiagsCheckError(diags, data.Set("prior", priorFlowsFilter(existing)), "Unable to set FlowsFilter prior")
+		diags = utils.DiagsCheckError(diags, data.Set("remote_owner", flowsFilter.Owner), "Unable to set FlowsFilter remote_owner")
+		diags = utils.DiagsCheckError(diags, data.Set("implementation", implementationState(m, implementation)), "Unable to set FlowsFilter implementation")
+		return diags
+	}
+
 	// Make API call
 	if id, err := c.FlowsFilter.Create(flowsFilter); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to create FlowsFilter"))
 	} else {
 		data.SetId(id)
+		diags = utils.DiagsCheckError(diags, data.Set("remote_owner", flowsFilter.Owner), "Unable to set FlowsFilter remote_owner")
+		diags = utils.DiagsCheckError(diags, data.Set("implementation", implementationState(m, implementation)), "Unable to set FlowsFilter implementation")
 	}
 
 	return diags
@@ -70,14 +240,15 @@ func readFlowsFilter(_ context.Context, data *schema.ResourceData, meta interfac
 		flowsFilter *client.FlowsFilter
 		err         error
 	)
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 
 	// We only allow one FlowsFilter object per org, so we do not need to retrieve by ID.
 	// We can just do a GET without any params
 	flowsFilter, err = c.FlowsFilter.Read()
 
 	if err != nil {
-		if isNotFoundError(err) {
+		if utils.IsNotFoundError(err) {
 			fmt.Printf("[WARN] Sym FlowsFilter not found, removing from state")
 			data.SetId("")
 			return nil
@@ -94,33 +265,62 @@ func readFlowsFilter(_ context.Context, data *schema.ResourceData, meta interfac
 	diags = utils.DiagsCheckError(diags, data.Set("implementation", flowsFilter.Implementation), "Unable to read FlowsFilter implementation")
 	diags = utils.DiagsCheckError(diags, data.Set("vars", flowsFilter.Vars), "Unable to read FlowsFilter vars")
 	diags = utils.DiagsCheckError(diags, data.Set("integrations", flowsFilter.Integrations), "Unable to read FlowsFilter integrations")
+	// `owner` is left as configured, so that it can be checked against the owner in Sym before each change.
+	diags = utils.DiagsCheckError(diags, data.Set("remote_owner", flowsFilter.Owner), "Unable to read FlowsFilter remote_owner")
 
 	// Decode the implementation so that it is human readable. Error if it is not decode-able
 	if decoded, err := base64.StdEncoding.DecodeString(flowsFilter.Implementation); err == nil {
-		diags = utils.DiagsCheckError(diags, data.Set("implementation", string(decoded)), "Unable to read FlowsFilter implementation")
+		diags = utils.DiagsCheckError(diags, data.Set("implementation", implementationState(m, string(decoded))), "Unable to read FlowsFilter implementation")
 	} else {
 		diags = append(diags, utils.DiagFromError(err, "Unable to read FlowsFilter implementation"))
 	}
 
+	diags = append(diags, setMetadata(data, "FlowsFilter", "", flowsFilter.Metadata)...)
+
 	return diags
 }
 
+// flowsFilterPatchFields are the FlowsFilter fields sent when their attributes change.
+var flowsFilterPatchFields = patchFields{
+	"implementation": {"implementation"},
+	"vars":           {"vars"},
+	"integrations":   {"integrations"},
+	"owner":          {"owner"},
+}
+
 // Update an existing flowsFilter using the HTTP client
 func updateFlowsFilter(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 
-	flowsFilter := client.FlowsFilter{
-		Vars:         getSettingsMap(data, "vars"),
-		Integrations: getSettingsMap(data, "integrations"),
+	flowsFilter := buildFlowsFilter(data)
+	implementation := getImplementation(data, "implementation")
+
+	patch, err := buildPatch(data, flowsFilter, flowsFilterPatchFields)
+	if err != nil {
+		return utils.DiagsFromError(err, "Unable to update FlowsFilter")
+	}
+	if len(patch) == 0 {
+		return diags
 	}
 
-	// base64 encode the implementation
-	implementation := data.Get("implementation").(string)
-	flowsFilter.Implementation = base64.StdEncoding.EncodeToString([]byte(implementation))
+	// Check ownership against the configured owner. The previously configured owner is also accepted, so that
+	// the owner itself may still be changed.
+	existing, err := c.FlowsFilter.Read()
+	if err != nil {
+		return utils.DiagsFromError(err, "Unable to update FlowsFilter")
+	}
+	oldOwner, newOwner := data.GetChange("owner")
+	if err := checkFlowsFilterOwner(existing, newOwner.(string)); err != nil && checkFlowsFilterOwner(existing, oldOwner.(string)) != nil {
+		return utils.DiagsFromError(err, "Unable to update FlowsFilter")
+	}
 
-	if _, err := c.FlowsFilter.Update(flowsFilter); err != nil {
+	if _, err := ifMatch(c, data).FlowsFilter.Patch(patch); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to update FlowsFilter"))
+	} else {
+		diags = utils.DiagsCheckError(diags, data.Set("remote_owner", flowsFilter.Owner), "Unable to set FlowsFilter remote_owner")
+		diags = utils.DiagsCheckError(diags, data.Set("implementation", implementationState(m, implementation)), "Unable to set FlowsFilter implementation")
 	}
 
 	return diags
@@ -129,9 +329,34 @@ func updateFlowsFilter(_ context.Context, data *schema.ResourceData, meta interf
 // Delete a flowsFilter using the HTTP client
 func deleteFlowsFilter(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
+
+	if diags = checkDeletion(data, c, "FlowsFilter", nil); diags.HasError() {
+		return diags
+	}
+
+	existing, err := c.FlowsFilter.Read()
+	if err != nil {
+		if utils.IsNotFoundError(err) {
+			return nil
+		}
+		return utils.DiagsFromError(err, "Unable to delete FlowsFilter")
+	}
+
+	// Never delete a FlowsFilter that now belongs to another sym_flows_filter. It is only removed from the state.
+	// The owner in the state is the last configured owner, since it is never read from Sym.
+	if err := checkFlowsFilterOwner(existing, data.Get("owner").(string)); err != nil {
+		return diag.Diagnostics{utils.DiagWarning("FlowsFilter was not deleted", err.Error())}
+	}
+
+	if prior := getPriorFlowsFilter(data); prior != nil && data.Get("on_destroy").(string) == flowsFilterOnDestroyRestore {
+		if _, err := ifMatch(c, data).FlowsFilter.Update(*prior); err != nil {
+			diags = append(diags, utils.DiagFromError(err, "Unable to restore FlowsFilter"))
+		}
+		return diags
+	}
 
-	if _, err := c.FlowsFilter.Delete(); err != nil {
+	if _, err := ifMatch(c, data).FlowsFilter.Delete(); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to delete FlowsFilter"))
 	}
 
@@ -1,10 +1,19 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
 )
 
 func TestAccSymFlowsFilter_basic(t *testing.T) {
@@ -12,8 +21,8 @@ func TestAccSymFlowsFilter_basic(t *testing.T) {
 	updateData := BuildTestData("flows-filter-updated")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: flowsFilterConfig(
@@ -47,6 +56,32 @@ func TestAccSymFlowsFilter_basic(t *testing.T) {
 	})
 }
 
+func TestAccSymFlowsFilter_hashImplementations(t *testing.T) {
+	createData := BuildTestData("flows-filter-hashed")
+	updateData := BuildTestData("flows-filter-hashed-updated")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: hashedFlowsFilterConfig(createData, "internal/testdata/before_impl.py"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("sym_flows_filter.this", "id"),
+					resource.TestCheckResourceAttr("sym_flows_filter.this", "implementation", utils.HashImpl("file('internal/testdata/before_impl.py')")),
+				),
+			},
+			{
+				Config: hashedFlowsFilterConfig(updateData, "internal/testdata/after_impl.py"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("sym_flows_filter.this", "id"),
+					resource.TestCheckResourceAttr("sym_flows_filter.this", "implementation", utils.HashImpl("file('internal/testdata/after_impl.py')")),
+				),
+			},
+		},
+	})
+}
+
 func flowsFilterConfig(data TestData, implPath string, vars map[string]string, integrations map[string]string) string {
 	// create the Slack Integration
 	slackData := integrationResource{
@@ -68,3 +103,79 @@ func flowsFilterConfig(data TestData, implPath string, vars map[string]string, i
 		},
 	)
 }
+
+func hashedFlowsFilterConfig(data TestData, implPath string) string {
+	return makeTerraformConfig(
+		providerResource{org: data.OrgSlug, hashImplementations: true},
+		flowsFilterResource{
+			terraformName:  "this",
+			implementation: fmt.Sprintf("file('%s')", implPath),
+		},
+	)
+}
+
+func Test_checkFlowsFilterOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		owner   string
+		current string
+		wantErr bool
+	}{
+		{"no-owner", "", "", false},
+		{"unowned-filter", "prod", "", false},
+		{"same-owner", "prod", "prod", false},
+		{"different-owner", "staging", "prod", true},
+		{"owned-filter-without-owner", "", "prod", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFlowsFilterOwner(&client.FlowsFilter{Owner: tt.current}, tt.owner)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, fmt.Sprintf("The FlowsFilter is owned by %q", tt.current))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_customizeDiffFlowsFilterRemoteOwner(t *testing.T) {
+	prior := FlowsFilter().TestResourceData()
+	prior.SetId("flows-filter-id")
+	require.NoError(t, prior.Set("implementation", "def get_flows(): pass"))
+	require.NoError(t, prior.Set("owner", "prod"))
+	require.NoError(t, prior.Set("remote_owner", "staging"))
+	require.NoError(t, prior.Set("adopt_existing", false))
+	require.NoError(t, prior.Set("on_destroy", flowsFilterOnDestroyDelete))
+	require.NoError(t, prior.Set("deletion_protection", false))
+
+	config := map[string]interface{}{"implementation": "def get_flows(): pass"}
+
+	// A FlowsFilter taken over by another owner is reported in remote_owner, without changing owner.
+	config["owner"] = "prod"
+	diff, err := FlowsFilter().Diff(context.Background(), prior.State(), terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	assert.NotContains(t, diff.Attributes, "owner")
+	assert.NotContains(t, diff.Attributes, "remote_owner")
+
+	config["owner"] = "dev"
+	diff, err = FlowsFilter().Diff(context.Background(), prior.State(), terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", diff.Attributes["remote_owner"].New)
+}
+
+func Test_getPriorFlowsFilter(t *testing.T) {
+	prior := &client.FlowsFilter{
+		Implementation: "cHJpbnQoJ2hlbGxvJyk=",
+		Vars:           client.Settings{"my_var": "is_cool"},
+		Integrations:   map[string]string{"slack_id": "slack-integration-id"},
+		Owner:          "prod",
+	}
+
+	data := schema.TestResourceDataRaw(t, FlowsFilter().Schema, map[string]interface{}{"implementation": "print('hi')"})
+	assert.Nil(t, getPriorFlowsFilter(data))
+
+	assert.NoError(t, data.Set("prior", priorFlowsFilter(prior)))
+	assert.Equal(t, prior, getPriorFlowsFilter(data))
+}
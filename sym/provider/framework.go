@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	sdkdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/tracing"
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+// This file contains the helpers shared by resources served by the framework provider (see frameworkProvider),
+// which mirror the wrappers applied to every SDK resource in Provider: withOrgs, withMetadata and traceResources.
+
+// frameworkDiags converts SDK diagnostics, e.g. from the helpers shared with SDK resources, to framework diagnostics.
+func frameworkDiags(diags sdkdiag.Diagnostics) diag.Diagnostics {
+	var converted diag.Diagnostics
+	for _, d := range diags {
+		if d.Severity == sdkdiag.Error {
+			converted.AddError(d.Summary, d.Detail)
+		} else {
+			converted.AddWarning(d.Summary, d.Detail)
+		}
+	}
+	return converted
+}
+
+// frameworkResource is embedded by every framework resource, and holds the providerMeta created by
+// frameworkProvider.Configure.
+type frameworkResource struct {
+	meta *providerMeta
+}
+
+func (r *frameworkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.meta, resp.Diagnostics = providerMetaFrom(req.ProviderData)
+}
+
+// providerMetaFrom returns the providerMeta passed to a resource's Configure. ProviderData is nil until the provider
+// has been configured, e.g. when validating configuration.
+func providerMetaFrom(providerData any) (*providerMeta, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if providerData == nil {
+		return nil, diags
+	}
+
+	m, ok := providerData.(*providerMeta)
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("Expected *providerMeta, got %T.", providerData))
+	}
+	return m, diags
+}
+
+// metaFor returns the providerMeta whose client manages the resource's org, and makes requests with the given
+// context. See providerMeta.forOrg.
+func (r *frameworkResource) metaFor(ctx context.Context, org types.String) (*providerMeta, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	m, err := r.meta.withContext(ctx).forOrg(org.ValueString())
+	if err != nil {
+		diags.AddError("Invalid org", err.Error())
+		return nil, diags
+	}
+	return m, diags
+}
+
+// idAttribute returns the schema of the computed `id` attribute.
+func idAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:      true,
+		Description:   "The ID of this resource in Sym.",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+}
+
+// orgAttribute returns the schema of the `org` attribute. See withOrgs.
+func orgAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:      true,
+		Description:   orgDescription,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+}
+
+// fullNameAttribute returns the schema of the computed `full_name` attribute, which must be planned by the
+// resource's ModifyPlan whenever `name` changes. See fullNameSchema.
+func fullNameAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:      true,
+		Description:   fullNameDescription,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+}
+
+// deletionProtectionAttribute returns the schema of the `deletion_protection` attribute. See utils.DeletionProtection.
+func deletionProtectionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+		Description: utils.DeletionProtectionDescription,
+	}
+}
+
+// withFrameworkMetadata adds the computed `updated_at`, `updated_by` and `etag` attributes to the given attributes.
+// They have no plan modifiers, so the framework plans them as unknown whenever the resource will be updated,
+// as customizeDiffMetadata does for SDK resources.
+func withFrameworkMetadata(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	attributes["updated_at"] = schema.StringAttribute{Computed: true, Description: updatedAtDescription}
+	attributes["updated_by"] = schema.StringAttribute{Computed: true, Description: updatedByDescription}
+	attributes["etag"] = schema.StringAttribute{Computed: true, Description: etagDescription}
+	return attributes
+}
+
+// metadataModel holds the attributes added by withFrameworkMetadata, and is embedded in the model of every
+// framework resource.
+type metadataModel struct {
+	UpdatedAt types.String `tfsdk:"updated_at"`
+	UpdatedBy types.String `tfsdk:"updated_by"`
+	ETag      types.String `tfsdk:"etag"`
+}
+
+// newMetadataModel returns the metadataModel for the given entity metadata.
+func newMetadataModel(metadata client.Metadata) metadataModel {
+	return metadataModel{
+		UpdatedAt: types.StringValue(metadata.UpdatedAt),
+		UpdatedBy: types.StringValue(metadata.UpdatedBy),
+		ETag:      types.StringValue(metadata.ETag),
+	}
+}
+
+// read sets the metadata read from Sym, warning if the entity was changed in Sym since it was last read.
+// See setMetadata.
+func (m *metadataModel) read(resource, name string, metadata client.Metadata) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	lastUpdatedAt := m.UpdatedAt.ValueString()
+	if lastUpdatedAt != "" && metadata.UpdatedAt != "" && metadata.UpdatedAt != lastUpdatedAt {
+		diags.Append(frameworkDiags(sdkdiag.Diagnostics{driftWarning(resource, name, metadata)})...)
+	}
+
+	*m = newMetadataModel(metadata)
+	return diags
+}
+
+// traceOperation starts the span of an operation on a framework resource with the given slug. The returned
+// function ends the span, recording the resource's ID and any errors. See traceResource.
+func traceOperation(ctx context.Context, resourceType, operation string, slug types.String) (context.Context, func(id types.String, diags diag.Diagnostics)) {
+	ctx, span := startResourceSpan(ctx, resourceType, operation, slug.ValueString())
+
+	return ctx, func(id types.String, diags diag.Diagnostics) {
+		if id.ValueString() != "" {
+			span.SetAttributes(tracing.ResourceIDKey.String(id.ValueString()))
+		}
+		for _, d := range diags.Errors() {
+			recordSpanError(span, d.Summary(), d.Detail())
+		}
+		span.End()
+	}
+}
+
+// rawAttribute returns the value of a top-level attribute of a raw plan, state or config.
+func rawAttribute(raw tftypes.Value, attribute string) tftypes.Value {
+	if raw.IsNull() || !raw.IsKnown() {
+		return raw
+	}
+
+	value, _, err := tftypes.WalkAttributePath(raw, tftypes.NewAttributePath().WithAttributeName(attribute))
+	if err != nil {
+		return tftypes.Value{}
+	}
+	return value.(tftypes.Value)
+}
+
+// planChanges returns a function that reports whether the plan changes any of the given attributes of the state,
+// which is always true when the resource will be created. It may be passed to patchOf.
+func planChanges(plan tfsdk.Plan, state tfsdk.State) func(attributes ...string) bool {
+	return func(attributes ...string) bool {
+		if state.Raw.IsNull() {
+			return true
+		}
+
+		for _, attribute := range attributes {
+			if !rawAttribute(plan.Raw, attribute).Equal(rawAttribute(state.Raw, attribute)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// unknownPlanAttributes returns those of the given attributes whose planned values are not yet wholly known.
+// See unknownAttributes.
+func unknownPlanAttributes(plan tfsdk.Plan, attributes []string) []string {
+	var unknown []string
+	for _, attribute := range attributes {
+		if !rawAttribute(plan.Raw, attribute).IsFullyKnown() {
+			unknown = append(unknown, attribute)
+		}
+	}
+	return unknown
+}
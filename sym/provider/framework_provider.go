@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/symopsio/terraform-provider-sym/sym/tracing"
+)
+
+var (
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+)
+
+// frameworkProvider serves the resources which have been ported from the SDK provider to terraform-plugin-framework,
+// as well as the ephemeral resources and provider-defined functions.
+// Both providers are served together by NewProviderServer, so they share the provider configuration, and each
+// resource type is served by exactly one of them.
+type frameworkProvider struct{}
+
+func newFrameworkProvider() provider.Provider {
+	return &frameworkProvider{}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "sym"
+}
+
+// Schema returns the SDK provider's schema, since the servers combined by NewProviderServer must have identical
+// provider schemas.
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	attributes, blocks := frameworkProviderSchema(Provider().Schema)
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}
+}
+
+// frameworkProviderSchema converts the attributes and blocks of the SDK provider's schema, which only uses
+// strings, bools, maps of strings, and blocks of those.
+func frameworkProviderSchema(sdk map[string]*sdkschema.Schema) (map[string]schema.Attribute, map[string]schema.Block) {
+	attributes := map[string]schema.Attribute{}
+	blocks := map[string]schema.Block{}
+
+	for name, s := range sdk {
+		switch s.Type {
+		case sdkschema.TypeString:
+			attributes[name] = schema.StringAttribute{Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description}
+		case sdkschema.TypeBool:
+			attributes[name] = schema.BoolAttribute{Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description}
+		case sdkschema.TypeMap:
+			attributes[name] = schema.MapAttribute{ElementType: types.StringType, Required: s.Required, Optional: s.Optional, Sensitive: s.Sensitive, Description: s.Description}
+		case sdkschema.TypeList:
+			elem, ok := s.Elem.(*sdkschema.Resource)
+			if !ok {
+				panic(fmt.Sprintf("provider setting %s is a list of unsupported type %T", name, s.Elem))
+			}
+			nestedAttributes, nestedBlocks := frameworkProviderSchema(elem.Schema)
+			blocks[name] = schema.ListNestedBlock{
+				Description: s.Description,
+				NestedObject: schema.NestedBlockObject{
+					Attributes: nestedAttributes,
+					Blocks:     nestedBlocks,
+				},
+			}
+		default:
+			panic(fmt.Sprintf("provider setting %s has unsupported type %s", name, s.Type))
+		}
+	}
+	return attributes, blocks
+}
+
+// frameworkProviderModel is the provider configuration, as read by the framework provider.
+type frameworkProviderModel struct {
+	Org                  types.String   `tfsdk:"org"`
+	JWTEnvVar            types.String   `tfsdk:"jwt_env_var"`
+	Orgs                 types.Map      `tfsdk:"orgs"`
+	HashImplementations  types.Bool     `tfsdk:"hash_implementations"`
+	WorkspaceName        types.String   `tfsdk:"workspace_name"`
+	RepoURL              types.String   `tfsdk:"repo_url"`
+	LockUIEdits          types.Bool     `tfsdk:"lock_ui_edits"`
+	DefaultNamePrefix    types.String   `tfsdk:"default_name_prefix"`
+	DefaultLabelTemplate types.String   `tfsdk:"default_label_template"`
+	ReadCache            types.Bool     `tfsdk:"read_cache"`
+	ValidateOnPlan       types.Bool     `tfsdk:"validate_on_plan"`
+	Tracing              []tracingModel `tfsdk:"tracing"`
+}
+
+// tracingModel is the `tracing` block of the provider configuration. See tracingSchema.
+type tracingModel struct {
+	OTLPEndpoint types.String `tfsdk:"otlp_endpoint"`
+	FilePath     types.String `tfsdk:"file_path"`
+}
+
+// Configure creates the framework provider's own providerMeta from the same configuration as providerConfigure.
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	orgs := map[string]string{}
+	resp.Diagnostics.Append(data.Orgs.ElementsAs(ctx, &orgs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cfg := providerConfig{
+		Org:                  data.Org.ValueString(),
+		JWTEnvVar:            data.JWTEnvVar.ValueString(),
+		Orgs:                 orgs,
+		HashImplementations:  data.HashImplementations.ValueBool(),
+		WorkspaceName:        data.WorkspaceName.ValueString(),
+		RepoURL:              data.RepoURL.ValueString(),
+		LockUIEdits:          data.LockUIEdits.ValueBool(),
+		DefaultNamePrefix:    data.DefaultNamePrefix.ValueString(),
+		DefaultLabelTemplate: data.DefaultLabelTemplate.ValueString(),
+		ReadCache:            data.ReadCache.ValueBool(),
+		ValidateOnPlan:       data.ValidateOnPlan.ValueBool(),
+	}
+	if len(data.Tracing) > 0 {
+		cfg.Tracing = tracing.Config{
+			OTLPEndpoint: data.Tracing[0].OTLPEndpoint.ValueString(),
+			FilePath:     data.Tracing[0].FilePath.ValueString(),
+		}
+	}
+
+	m, diags := configure(ctx, cfg)
+	resp.Diagnostics.Append(frameworkDiags(diags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.ResourceData = m
+	resp.DataSourceData = m
+	resp.EphemeralResourceData = m
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newFlowResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// EphemeralResources returns the ephemeral resources, which are only served by the framework provider as the SDK
+// does not support them. They require Terraform 1.10 or later.
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newTokenEphemeralResource,
+	}
+}
+
+// Functions returns the provider-defined functions, which require Terraform 1.8 or later.
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		newParseSRNFunction,
+		newBuildSRNFunction,
+		newSlugifyFunction,
+		newImplBundleFunction,
+	}
+}
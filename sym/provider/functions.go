@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+var (
+	_ function.Function = &parseSRNFunction{}
+	_ function.Function = &buildSRNFunction{}
+	_ function.Function = &slugifyFunction{}
+	_ function.Function = &implBundleFunction{}
+)
+
+// srnAttributeTypes are the attributes of the object returned by parse_srn.
+var srnAttributeTypes = map[string]attr.Type{
+	"org":        types.StringType,
+	"model":      types.StringType,
+	"slug":       types.StringType,
+	"version":    types.StringType,
+	"identifier": types.StringType,
+}
+
+// srnModel is the object returned by parse_srn. The identifier is null if the SRN has none.
+type srnModel struct {
+	Org        types.String `tfsdk:"org"`
+	Model      types.String `tfsdk:"model"`
+	Slug       types.String `tfsdk:"slug"`
+	Version    types.String `tfsdk:"version"`
+	Identifier types.String `tfsdk:"identifier"`
+}
+
+// parseSRNFunction implements provider::sym::parse_srn.
+type parseSRNFunction struct{}
+
+func newParseSRNFunction() function.Function {
+	return &parseSRNFunction{}
+}
+
+func (f *parseSRNFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_srn"
+}
+
+func (f *parseSRNFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse a Sym Resource Name",
+		Description: "Splits a Sym Resource Name (SRN) of the form `<org>:<model>:<slug>:<version>[:<identifier>]` into its components. The `identifier` is null if the SRN has none.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "srn", Description: "The SRN to parse, e.g. `sym:template:approval:1.0.0`."},
+		},
+		Return: function.ObjectReturn{AttributeTypes: srnAttributeTypes},
+	}
+}
+
+func (f *parseSRNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var srn string
+	resp.Error = req.Arguments.Get(ctx, &srn)
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := utils.ParseSRN(srn)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	identifier := types.StringNull()
+	if parsed.Identifier != "" {
+		identifier = types.StringValue(parsed.Identifier)
+	}
+	resp.Error = resp.Result.Set(ctx, srnModel{
+		Org:        types.StringValue(parsed.Org),
+		Model:      types.StringValue(parsed.Model),
+		Slug:       types.StringValue(parsed.Slug),
+		Version:    types.StringValue(parsed.Version),
+		Identifier: identifier,
+	})
+}
+
+// buildSRNFunction implements provider::sym::build_srn.
+type buildSRNFunction struct{}
+
+func newBuildSRNFunction() function.Function {
+	return &buildSRNFunction{}
+}
+
+func (f *buildSRNFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_srn"
+}
+
+func (f *buildSRNFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a Sym Resource Name",
+		Description: "Joins the given components into a Sym Resource Name (SRN) of the form `<org>:<model>:<slug>:<version>[:<identifier>]`, validating each of them.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "org", Description: "The slug of the Sym org, e.g. `sym` for entities provided by Sym."},
+			function.StringParameter{Name: "model", Description: "The type of the entity, e.g. `template`."},
+			function.StringParameter{Name: "slug", Description: "The slug of the entity, e.g. `approval`."},
+			function.StringParameter{Name: "version", Description: "The version of the entity, either `latest` or a semantic version like `1.0.0`."},
+		},
+		VariadicParameter: function.StringParameter{Name: "identifier", Description: "An optional identifier of a specific instance of the entity."},
+		Return:            function.StringReturn{},
+	}
+}
+
+func (f *buildSRNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var srn utils.SRN
+	var identifiers []string
+	resp.Error = req.Arguments.Get(ctx, &srn.Org, &srn.Model, &srn.Slug, &srn.Version, &identifiers)
+	if resp.Error != nil {
+		return
+	}
+
+	if len(identifiers) > 1 {
+		resp.Error = function.NewArgumentFuncError(4, fmt.Sprintf("at most one identifier may be given, got %d", len(identifiers)))
+		return
+	}
+	if len(identifiers) == 1 {
+		srn.Identifier = identifiers[0]
+	}
+
+	if err := srn.Validate(); err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Invalid SRN: %s", err))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, srn.String())
+}
+
+// slugifyFunction implements provider::sym::slugify.
+type slugifyFunction struct{}
+
+func newSlugifyFunction() function.Function {
+	return &slugifyFunction{}
+}
+
+func (f *slugifyFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "slugify"
+}
+
+func (f *slugifyFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Convert text to a Sym slug",
+		Description: "Converts the given text to a slug that is valid as the `name` of a Sym resource: lowercase letters, numbers, " +
+			"underscores, and dashes, where every run of other characters becomes a single dash, and leading and trailing " +
+			"dashes and underscores are removed. For example, `AWS  Prod / Admins` becomes `aws-prod-admins`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "text", Description: "The text to convert."},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *slugifyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var text string
+	resp.Error = req.Arguments.Get(ctx, &text)
+	if resp.Error != nil {
+		return
+	}
+
+	slug := utils.Slugify(text)
+	if slug == "" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("%q does not contain any characters that are valid in a slug", text))
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, slug)
+}
+
+// implBundleFunction implements provider::sym::impl_bundle.
+type implBundleFunction struct{}
+
+func newImplBundleFunction() function.Function {
+	return &implBundleFunction{}
+}
+
+func (f *implBundleFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "impl_bundle"
+}
+
+func (f *implBundleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Bundle a Sym implementation with shared helpers",
+		Description: "Reads the given implementation file and any number of helper files, and returns their contents as one " +
+			"implementation, with the helpers first so that the implementation can use them. Like `file()`, relative paths " +
+			"are relative to the working directory, so paths in modules should start with `path.module`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{Name: "path", Description: "The path to the implementation file."},
+		},
+		VariadicParameter: function.StringParameter{Name: "helpers", Description: "The paths to the helper files, in the order they should be included."},
+		Return:            function.StringReturn{},
+	}
+}
+
+func (f *implBundleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var path string
+	var helpers []string
+	resp.Error = req.Arguments.Get(ctx, &path, &helpers)
+	if resp.Error != nil {
+		return
+	}
+
+	impls := make([]string, 0, len(helpers)+1)
+	for _, helper := range helpers {
+		contents, err := os.ReadFile(helper)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Unable to read helper: %s", err))
+			return
+		}
+		impls = append(impls, string(contents))
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Unable to read implementation: %s", err))
+		return
+	}
+	impls = append(impls, string(contents))
+
+	resp.Error = resp.Result.Set(ctx, utils.BundleImpls(impls...))
+}
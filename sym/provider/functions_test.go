@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callFunction calls a provider-defined function through the provider server, as Terraform would, and returns
+// its result as the given type.
+func callFunction(t *testing.T, name string, resultType tftypes.Type, args ...string) (tftypes.Value, *tfprotov5.FunctionError) {
+	ctx := context.Background()
+	providerServer, err := NewProviderServer(ctx)
+	require.NoError(t, err)
+	server := providerServer()
+
+	arguments := make([]*tfprotov5.DynamicValue, len(args))
+	for i, arg := range args {
+		value, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, arg))
+		require.NoError(t, err)
+		arguments[i] = &value
+	}
+
+	resp, err := server.CallFunction(ctx, &tfprotov5.CallFunctionRequest{Name: name, Arguments: arguments})
+	require.NoError(t, err)
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+
+	result, err := resp.Result.Unmarshal(resultType)
+	require.NoError(t, err)
+	return result, nil
+}
+
+// callStringFunction calls a provider-defined function which returns a string.
+func callStringFunction(t *testing.T, name string, args ...string) (string, *tfprotov5.FunctionError) {
+	result, funcErr := callFunction(t, name, tftypes.String, args...)
+	if funcErr != nil {
+		return "", funcErr
+	}
+
+	var s string
+	require.NoError(t, result.As(&s))
+	return s, nil
+}
+
+func TestParseSRNFunction(t *testing.T) {
+	srnType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"org":        tftypes.String,
+		"model":      tftypes.String,
+		"slug":       tftypes.String,
+		"version":    tftypes.String,
+		"identifier": tftypes.String,
+	}}
+
+	result, funcErr := callFunction(t, "parse_srn", srnType, "sym:template:approval:1.0.0")
+	require.Nil(t, funcErr)
+
+	var attributes map[string]tftypes.Value
+	require.NoError(t, result.As(&attributes))
+	assert.True(t, attributes["slug"].Equal(tftypes.NewValue(tftypes.String, "approval")))
+	assert.True(t, attributes["version"].Equal(tftypes.NewValue(tftypes.String, "1.0.0")))
+	assert.True(t, attributes["identifier"].IsNull())
+
+	_, funcErr = callFunction(t, "parse_srn", srnType, "sym:template")
+	require.NotNil(t, funcErr)
+	assert.Equal(t, int64(0), *funcErr.FunctionArgument)
+	assert.Contains(t, funcErr.Text, "expected 4 or 5 components")
+}
+
+func TestBuildSRNFunction(t *testing.T) {
+	srn, funcErr := callStringFunction(t, "build_srn", "my-org", "flow", "access", "latest")
+	require.Nil(t, funcErr)
+	assert.Equal(t, "my-org:flow:access:latest", srn)
+
+	srn, funcErr = callStringFunction(t, "build_srn", "my-org", "flow", "access", "1.2.3", "abc")
+	require.Nil(t, funcErr)
+	assert.Equal(t, "my-org:flow:access:1.2.3:abc", srn)
+
+	_, funcErr = callStringFunction(t, "build_srn", "my-org", "flow", "access", "latest", "a", "b")
+	require.NotNil(t, funcErr)
+	assert.Contains(t, funcErr.Text, "at most one identifier")
+
+	_, funcErr = callStringFunction(t, "build_srn", "my-org", "flow", "access:request", "latest")
+	require.NotNil(t, funcErr)
+	assert.Contains(t, funcErr.Text, `slug "access:request"`)
+}
+
+func TestSlugifyFunction(t *testing.T) {
+	slug, funcErr := callStringFunction(t, "slugify", "AWS  Prod / Admins")
+	require.Nil(t, funcErr)
+	assert.Equal(t, "aws-prod-admins", slug)
+
+	_, funcErr = callStringFunction(t, "slugify", "!!!")
+	require.NotNil(t, funcErr)
+	assert.Contains(t, funcErr.Text, "does not contain any characters")
+}
+
+func TestImplBundleFunction(t *testing.T) {
+	dir := t.TempDir()
+	impl := filepath.Join(dir, "impl.py")
+	helper := filepath.Join(dir, "helper.py")
+	require.NoError(t, os.WriteFile(impl, []byte("@reducer\ndef get_approvers(event):\n    return approvers()\n"), 0o600))
+	require.NoError(t, os.WriteFile(helper, []byte("def approvers():\r\n    return []\r\n"), 0o600))
+
+	bundle, funcErr := callStringFunction(t, "impl_bundle", impl, helper)
+	require.Nil(t, funcErr)
+	assert.Equal(t, "def approvers():\n    return []\n\n\n@reducer\ndef get_approvers(event):\n    return approvers()\n", bundle)
+
+	_, funcErr = callStringFunction(t, "impl_bundle", impl, filepath.Join(dir, "missing.py"))
+	require.NotNil(t, funcErr)
+	assert.Equal(t, int64(1), *funcErr.FunctionArgument)
+	assert.Contains(t, funcErr.Text, "Unable to read helper")
+}
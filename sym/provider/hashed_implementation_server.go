@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+// hashedImplementationAttributes are the attributes of framework resources which are stored as hashes if the
+// provider's `hash_implementations` setting is enabled.
+var hashedImplementationAttributes = map[string]bool{
+	"implementation": true,
+	"on_change":      true,
+}
+
+// hashedImplementationServer is a tfprotov5.ProviderServer that lets framework resources store hashes of their
+// implementations in the Terraform state. Terraform requires planned values to match the config, and applied values
+// to match the plan, except for resources using the legacy SDK type system, as the SDK resources storing hashes do.
+// So plans and applies of hashed implementations are marked as using it.
+type hashedImplementationServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s hashedImplementationServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err == nil && resp != nil && s.hasHashedImplementation(ctx, req.TypeName, resp.PlannedState) {
+		resp.UnsafeToUseLegacyTypeSystem = true
+	}
+	return resp, err
+}
+
+func (s hashedImplementationServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.ApplyResourceChange(ctx, req)
+	if err == nil && resp != nil && s.hasHashedImplementation(ctx, req.TypeName, resp.NewState) {
+		resp.UnsafeToUseLegacyTypeSystem = true
+	}
+	return resp, err
+}
+
+// hasHashedImplementation returns whether any hashedImplementationAttributes of a resource's plan or state are hashes.
+func (s hashedImplementationServer) hasHashedImplementation(ctx context.Context, typeName string, value *tfprotov5.DynamicValue) bool {
+	if value == nil {
+		return false
+	}
+
+	schemaResp, err := s.ProviderServer.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil || schemaResp.ResourceSchemas[typeName] == nil {
+		return false
+	}
+
+	raw, err := value.Unmarshal(schemaResp.ResourceSchemas[typeName].ValueType())
+	if err != nil {
+		return false
+	}
+
+	hashed := false
+	_ = tftypes.Walk(raw, func(p *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+		steps := p.Steps()
+		if len(steps) == 0 || !v.IsKnown() || v.IsNull() || !v.Type().Is(tftypes.String) {
+			return true, nil
+		}
+
+		if name, ok := steps[len(steps)-1].(tftypes.AttributeName); ok && hashedImplementationAttributes[string(name)] {
+			var s string
+			hashed = v.As(&s) == nil && utils.IsHashedValue(s)
+		}
+		return !hashed, nil
+	})
+	return hashed
+}
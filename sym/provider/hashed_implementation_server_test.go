@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+func Test_hashedImplementationServer(t *testing.T) {
+	planned := func(implementation interface{}) *tfprotov5.DynamicValue {
+		valueType := implementationSchema.ValueType()
+		value := tftypes.NewValue(valueType, map[string]tftypes.Value{"implementation": tftypes.NewValue(tftypes.String, implementation)})
+		state, err := tfprotov5.NewDynamicValue(valueType, value)
+		require.NoError(t, err)
+		return &state
+	}
+
+	tests := []struct {
+		name       string
+		planned    interface{}
+		wantLegacy bool
+	}{
+		{"hashed", utils.HashImpl("def get_flows():\n    return []\n"), true},
+		{"plaintext", "def get_flows():\n    return []\n", false},
+		{"null", nil, false},
+		{"unknown", tftypes.UnknownValue, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := hashedImplementationServer{planningServer{planned: planned(tt.planned)}}
+			resp, err := s.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{TypeName: "sym_strategy"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLegacy, resp.UnsafeToUseLegacyTypeSystem)
+		})
+	}
+}
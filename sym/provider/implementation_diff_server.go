@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// implementationDiffResources maps the SDK resources whose plans warn about changes to their `implementation`
+// to the names used in the warnings.
+var implementationDiffResources = map[string]string{
+	"sym_flows_filter": "FlowsFilter",
+}
+
+// implementationDiffServer is a tfprotov5.ProviderServer that adds a warning containing the diff of the
+// `implementation` to the plans of implementationDiffResources. The SDK's CustomizeDiff cannot return warnings,
+// so they are added to the plan responses of the SDK provider instead. Framework resources warn from ModifyPlan.
+type implementationDiffServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s implementationDiffServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	resource, ok := implementationDiffResources[req.TypeName]
+	if err != nil || !ok || resp == nil || req.PriorState == nil || resp.PlannedState == nil {
+		return resp, err
+	}
+
+	schemaResp, err := s.ProviderServer.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil || schemaResp.ResourceSchemas[req.TypeName] == nil {
+		return resp, err
+	}
+	valueType := schemaResp.ResourceSchemas[req.TypeName].ValueType()
+
+	old, oldOk := dynamicImplementation(req.PriorState, valueType)
+	new, newOk := dynamicImplementation(resp.PlannedState, valueType)
+	if !oldOk || !newOk {
+		// The resource is being created or destroyed, or its new implementation is not known yet.
+		return resp, nil
+	}
+
+	for _, d := range implementationDiff(resource, old, new) {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  d.Summary,
+			Detail:   d.Detail,
+		})
+	}
+	return resp, nil
+}
+
+// dynamicImplementation returns the `implementation` of a resource's state or plan, and whether it is known.
+func dynamicImplementation(value *tfprotov5.DynamicValue, valueType tftypes.Type) (string, bool) {
+	raw, err := value.Unmarshal(valueType)
+	if err != nil {
+		return "", false
+	}
+
+	implementation := rawAttribute(raw, "implementation")
+	if implementation.IsNull() || !implementation.IsKnown() {
+		return "", false
+	}
+
+	var s string
+	if err := implementation.As(&s); err != nil {
+		return "", false
+	}
+	return s, true
+}
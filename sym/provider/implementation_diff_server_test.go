@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+// planningServer is a tfprotov5.ProviderServer that plans the given state for every resource.
+type planningServer struct {
+	tfprotov5.ProviderServer
+	planned *tfprotov5.DynamicValue
+}
+
+var implementationSchema = &tfprotov5.Schema{
+	Block: &tfprotov5.SchemaBlock{
+		Attributes: []*tfprotov5.SchemaAttribute{{Name: "implementation", Type: tftypes.String, Optional: true}},
+	},
+}
+
+func (s planningServer) GetProviderSchema(context.Context, *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	return &tfprotov5.GetProviderSchemaResponse{
+		ResourceSchemas: map[string]*tfprotov5.Schema{"sym_flows_filter": implementationSchema, "sym_strategy": implementationSchema},
+	}, nil
+}
+
+func (s planningServer) PlanResourceChange(context.Context, *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	return &tfprotov5.PlanResourceChangeResponse{PlannedState: s.planned}, nil
+}
+
+func Test_implementationDiffServer(t *testing.T) {
+	implementationState := func(implementation interface{}) *tfprotov5.DynamicValue {
+		valueType := implementationSchema.ValueType()
+		value := tftypes.NewValue(valueType, map[string]tftypes.Value{"implementation": tftypes.NewValue(tftypes.String, implementation)})
+		if implementation == nil {
+			value = tftypes.NewValue(valueType, nil)
+		}
+		state, err := tfprotov5.NewDynamicValue(valueType, value)
+		require.NoError(t, err)
+		return &state
+	}
+
+	tests := []struct {
+		name         string
+		typeName     string
+		prior        interface{}
+		planned      interface{}
+		wantWarnings int
+	}{
+		{"changed", "sym_flows_filter", "def get_flows():\n    return []\n", "def get_flows():\n    return None\n", 1},
+		{"unchanged", "sym_flows_filter", "def get_flows():\n    return []\n", "def get_flows():\n    return []\n", 0},
+		{"created", "sym_flows_filter", nil, "def get_flows():\n    return []\n", 0},
+		{"destroyed", "sym_flows_filter", "def get_flows():\n    return []\n", nil, 0},
+		{"unknown", "sym_flows_filter", "def get_flows():\n    return []\n", tftypes.UnknownValue, 0},
+		{"hashed", "sym_flows_filter", utils.HashImpl("def get_flows():\n    return None\n"), "def get_flows():\n    return []\n", 0},
+		{"other-resource", "sym_strategy", "def get_flows():\n    return []\n", "def get_flows():\n    return None\n", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := implementationDiffServer{planningServer{planned: implementationState(tt.planned)}}
+			resp, err := s.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+				TypeName:   tt.typeName,
+				PriorState: implementationState(tt.prior),
+			})
+			require.NoError(t, err)
+
+			assert.Len(t, resp.Diagnostics, tt.wantWarnings)
+			for _, d := range resp.Diagnostics {
+				assert.Equal(t, tfprotov5.DiagnosticSeverityWarning, d.Severity)
+				assert.Equal(t, "FlowsFilter implementation changed", d.Summary)
+				assert.Contains(t, d.Detail, "-    return []")
+			}
+		})
+	}
+}
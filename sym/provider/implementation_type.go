@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+var (
+	_ basetypes.StringTypable                    = implementationType{}
+	_ basetypes.StringValuableWithSemanticEquals = implementationValue{}
+	_ validator.String                           = implementationValidator{}
+)
+
+// implementationType is the type of Python implementation attributes of framework resources. Implementations
+// which differ only in line endings, trailing whitespace, and final newlines are semantically equal, so the
+// configured implementation is kept in the state when Sym returns a normalized copy of it. An implementation
+// stored as a hash is semantically equal to any implementation with that hash.
+// See utils.SuppressEquivalentImplDiffs.
+type implementationType struct {
+	basetypes.StringType
+}
+
+func (t implementationType) Equal(o attr.Type) bool {
+	other, ok := o.(implementationType)
+	return ok && t.StringType.Equal(other.StringType)
+}
+
+func (t implementationType) String() string {
+	return "implementationType"
+}
+
+func (t implementationType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return implementationValue{StringValue: in}, nil
+}
+
+func (t implementationType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	value, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := value.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", value)
+	}
+	return implementationValue{StringValue: stringValue}, nil
+}
+
+func (t implementationType) ValueType(_ context.Context) attr.Value {
+	return implementationValue{}
+}
+
+// implementationValue is a value of implementationType.
+type implementationValue struct {
+	basetypes.StringValue
+}
+
+// newImplementationValue returns a known implementationValue.
+func newImplementationValue(implementation string) implementationValue {
+	return implementationValue{StringValue: basetypes.NewStringValue(implementation)}
+}
+
+// newImplementationNull returns a null implementationValue.
+func newImplementationNull() implementationValue {
+	return implementationValue{StringValue: basetypes.NewStringNull()}
+}
+
+// stateValue returns the value the implementation should be stored as in the Terraform state.
+// See implementationState.
+func (v implementationValue) stateValue(m *providerMeta) implementationValue {
+	if v.IsNull() || v.IsUnknown() {
+		return v
+	}
+	return newImplementationValue(implementationState(m, v.ValueString()))
+}
+
+func (v implementationValue) Equal(o attr.Value) bool {
+	other, ok := o.(implementationValue)
+	return ok && v.StringValue.Equal(other.StringValue)
+}
+
+func (v implementationValue) Type(_ context.Context) attr.Type {
+	return implementationType{}
+}
+
+func (v implementationValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(implementationValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("Expected implementationValue, got %T.", newValuable))
+		return false, diags
+	}
+
+	prior, proposed := v.ValueString(), newValue.ValueString()
+	if utils.IsHashedValue(prior) {
+		// The implementation is stored as a hash if the provider's `hash_implementations` setting is enabled.
+		return prior == proposed || prior == utils.HashImpl(proposed), diags
+	}
+	return utils.NormalizeImpl(prior) == utils.NormalizeImpl(proposed), diags
+}
+
+// implementationValidator rejects implementations that look like file paths. See ImplementationValidation.
+type implementationValidator struct{}
+
+func (v implementationValidator) Description(_ context.Context) string {
+	return "value must be file contents, not a file path"
+}
+
+func (v implementationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v implementationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, d := range ImplementationValidation(req.ConfigValue.ValueString(), cty.Path{}) {
+		resp.Diagnostics.AddAttributeError(req.Path, d.Summary, d.Detail)
+	}
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+func Test_implementationValue_StringSemanticEquals(t *testing.T) {
+	const implementation = "def get_flows():\n    return []\n"
+
+	tests := []struct {
+		name     string
+		prior    string
+		proposed string
+		want     bool
+	}{
+		{"equal", implementation, implementation, true},
+		{"normalized", implementation, "def get_flows():  \r\n    return []", true},
+		{"changed", implementation, "def get_flows():\n    return None\n", false},
+		{"hashed", utils.HashImpl(implementation), implementation, true},
+		{"hashed-normalized", utils.HashImpl(implementation), "def get_flows():  \r\n    return []", true},
+		{"hashed-changed", utils.HashImpl(implementation), "def get_flows():\n    return None\n", false},
+		{"both-hashed", utils.HashImpl(implementation), utils.HashImpl(implementation), true},
+		// A plaintext implementation is replaced by its hash when `hash_implementations` is enabled.
+		{"newly-hashed", implementation, utils.HashImpl(implementation), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := newImplementationValue(tt.prior).StringSemanticEquals(context.Background(), newImplementationValue(tt.proposed))
+			assert.False(t, diags.HasError())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
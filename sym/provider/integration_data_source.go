@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	"github.com/symopsio/terraform-provider-sym/sym/client"
 	"github.com/symopsio/terraform-provider-sym/sym/utils"
 )
 
@@ -26,7 +25,7 @@ func DataSourceIntegration() *schema.Resource {
 
 func dataSourceIntegrationRead(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	name := data.Get("name").(string)
 	integrationType := data.Get("type").(string)
 
@@ -12,8 +12,8 @@ func TestAccSymDataSourceIntegration_slack(t *testing.T) {
 	data := BuildTestData("slack-integration")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: slackDataSourceIntegration(data),
@@ -32,8 +32,8 @@ func TestAccSymDataSourceIntegration_permissionContext(t *testing.T) {
 	data := BuildTestData("runtime-context")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: permissionContextDataSourceIntegration(data),
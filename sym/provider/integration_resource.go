@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/symopsio/terraform-provider-sym/sym/client"
@@ -18,29 +19,63 @@ func Integration() *schema.Resource {
 		ReadContext:   readIntegration,
 		UpdateContext: updateIntegration,
 		DeleteContext: deleteIntegration,
+		CustomizeDiff: customdiff.All(
+			customizeDiffFullName,
+			validateOnPlan("Integration", integrationPatchFields, validateIntegrationOnPlan),
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: getNameAndTypeImporter("integration"),
+			StateContext: getImporter("integration", integrationImportCandidates),
 		},
 		Schema: map[string]*schema.Schema{
-			"type":        utils.Required(schema.TypeString, "The type of the Integration. E.g. 'slack' or 'pagerduty'"),
-			"settings":    utils.SettingsMap("A map of settings specific to this type of Integration."),
-			"name":        utils.RequiredCaseInsensitiveString("A unique identifier for this Integration."),
-			"external_id": utils.Required(schema.TypeString, "The external ID for this Integration. E.g. Slack workspace ID for Slack Integration"),
-			"label":       utils.Optional(schema.TypeString, "An optional label."),
+			"type":                utils.Required(schema.TypeString, "The type of the Integration. E.g. 'slack' or 'pagerduty'"),
+			"settings":            utils.SettingsMap("A map of settings specific to this type of Integration."),
+			"sensitive_settings":  utils.SensitiveSettingsMap("this type of Integration"),
+			"name":                utils.RequiredCaseInsensitiveString("A unique identifier for this Integration."),
+			"full_name":           fullNameSchema(),
+			"external_id":         utils.Required(schema.TypeString, "The external ID for this Integration. E.g. Slack workspace ID for Slack Integration"),
+			"label":               utils.Optional(schema.TypeString, "An optional label."),
+			"deletion_protection": utils.DeletionProtection(),
 		},
 	}
 }
 
-func createIntegration(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+// integrationImportCandidates lists every Integration that may be imported as a sym_integration.
+var integrationImportCandidates = importCandidatesOf(
+	func(c *client.ApiClient) ([]client.Integration, error) { return c.Integration.List(nil) },
+	func(integration client.Integration) importCandidate {
+		return importCandidate{Id: integration.Id, Slug: integration.Name, Qualifiers: []string{integration.Type}}
+	},
+)
 
-	integration := client.Integration{
+// buildIntegration returns the Integration configured by the resource.
+func buildIntegration(data resourceGetter, m *providerMeta) (client.Integration, diag.Diagnostics) {
+	settings, diags := getMergedSettings(data)
+
+	return client.Integration{
 		Type:       data.Get("type").(string),
-		Settings:   getSettings(data),
-		Name:       data.Get("name").(string),
+		Settings:   settings,
+		Name:       m.Naming.fullName(data.Get("name").(string)),
 		ExternalId: data.Get("external_id").(string),
-		Label:      data.Get("label").(string),
+		Label:      m.Naming.fullLabel(data.Get("label").(string)),
+	}, diags
+}
+
+func validateIntegrationOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	integration, diags := buildIntegration(d, m)
+	if err := diagsError(diags); err != nil {
+		return err
+	}
+	integration.Id = d.Id()
+	return m.Client.Integration.Validate(integration)
+}
+
+func createIntegration(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	c := m.Client
+
+	integration, diags := buildIntegration(data, m)
+	if diags.HasError() {
+		return diags
 	}
 
 	id, err := c.Integration.Create(integration)
@@ -48,6 +83,7 @@ func createIntegration(_ context.Context, data *schema.ResourceData, meta interf
 		diags = utils.DiagsCheckError(diags, err, "Unable to create Integration")
 	} else {
 		data.SetId(id)
+		diags = utils.DiagsCheckError(diags, setHashedSensitiveSettings(data), "Unable to set Integration sensitive_settings")
 	}
 	return diags
 }
@@ -58,21 +94,13 @@ func readIntegration(_ context.Context, data *schema.ResourceData, meta interfac
 		integration *client.Integration
 		err         error
 	)
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 	id := data.Id()
 
-	idParts, parseErr := resourceIdToParts(id, "integration")
-	if parseErr == nil {
-		// If the ID was parsed as `TYPE:SLUG` successfully, perform a lookup using those values.
-		// This means we are in a `terraform import` scenario.
-		integration, err = c.Integration.Find(idParts.Slug, idParts.Subtype)
-	} else {
-		// If the ID could not be parsed as `TYPE:SLUG`, we are doing a normal read at apply-time.
-		integration, err = c.Integration.Read(id)
-	}
-
+	integration, err = c.Integration.Read(id)
 	if err != nil {
-		if isNotFoundError(err) {
+		if utils.IsNotFoundError(err) {
 			log.Println(notFoundWarning("Integration", id))
 			data.SetId("")
 			return nil
@@ -81,34 +109,49 @@ func readIntegration(_ context.Context, data *schema.ResourceData, meta interfac
 		return diags
 	}
 
-	// In the case of a normal read, ID will already be set and this is redundant.
-	// In the case of a `terraform import`, we need to set ID since it was previously TYPE:SLUG.
-	// This must happen below the error checking in case the lookup failed.
-	data.SetId(integration.Id)
-
 	diags = utils.DiagsCheckError(diags, data.Set("type", integration.Type), "Unable to read Integration type")
-	diags = utils.DiagsCheckError(diags, data.Set("name", integration.Name), "Unable to read Integration name")
-	diags = utils.DiagsCheckError(diags, data.Set("settings", integration.Settings), "Unable to read Integration settings")
+	diags = utils.DiagsCheckError(diags, data.Set("name", m.Naming.readName(data.Get("name").(string), integration.Name)), "Unable to read Integration name")
+	diags = utils.DiagsCheckError(diags, data.Set("full_name", integration.Name), "Unable to read Integration full_name")
+	diags = append(diags, setMergedSettings(data, integration.Settings)...)
 	diags = utils.DiagsCheckError(diags, data.Set("external_id", integration.ExternalId), "Unable to read Integration external_id")
-	diags = utils.DiagsCheckError(diags, data.Set("label", integration.Label), "Unable to read Integration label")
+	diags = utils.DiagsCheckError(diags, data.Set("label", m.Naming.readLabel(data.Get("label").(string), integration.Label)), "Unable to read Integration label")
+
+	diags = append(diags, setMetadata(data, "Integration", integration.Name, integration.Metadata)...)
 
 	return diags
 }
 
+// integrationPatchFields are the Integration fields sent when their attributes change.
+var integrationPatchFields = patchFields{
+	"type":        {"type"},
+	"slug":        {"name", "full_name"},
+	"settings":    {"settings", "sensitive_settings"},
+	"external_id": {"external_id"},
+	"label":       {"label"},
+}
+
 func updateIntegration(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 
-	integration := client.Integration{
-		Id:         data.Id(),
-		Type:       data.Get("type").(string),
-		Name:       data.Get("name").(string),
-		Settings:   getSettings(data),
-		ExternalId: data.Get("external_id").(string),
-		Label:      data.Get("label").(string),
+	integration, diags := buildIntegration(data, m)
+	if diags.HasError() {
+		return diags
 	}
-	if _, err := c.Integration.Update(integration); err != nil {
+	integration.Id = data.Id()
+	patch, err := buildPatch(data, integration, integrationPatchFields)
+	if err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to update Integration"))
+		return diags
+	}
+	if len(patch) == 0 {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).Integration.Patch(data.Id(), patch); err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to update Integration"))
+	} else {
+		diags = utils.DiagsCheckError(diags, setHashedSensitiveSettings(data), "Unable to set Integration sensitive_settings")
 	}
 
 	return diags
@@ -116,10 +159,14 @@ func updateIntegration(_ context.Context, data *schema.ResourceData, meta interf
 
 func deleteIntegration(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	if _, err := c.Integration.Delete(id); err != nil {
+	if diags = checkDeletion(data, c, "Integration", integrationDependents); diags.HasError() {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).Integration.Delete(id); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to delete Integration"))
 	}
 
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
 )
 
 const roleArnPrefix = "arn:aws:iam::123456789012:role/sym"
@@ -16,8 +18,8 @@ func TestAccSymIntegration_slack(t *testing.T) {
 	updateData := BuildTestData("updated-slack-integration")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: slackIntegrationConfig(createData, "Slack Integration", "T12345"),
@@ -46,8 +48,8 @@ func TestAccSymIntegration_permissionContext(t *testing.T) {
 	updateData := BuildTestData("updated-runtime-context")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: permissionContextIntegrationConfig(createData, "Runtime Context", "5555555", "123", "us-east-1", "foo"),
@@ -79,13 +81,41 @@ func TestAccSymIntegration_permissionContext(t *testing.T) {
 	})
 }
 
+func TestAccSymIntegration_sensitiveSettings(t *testing.T) {
+	createData := BuildTestData("sensitive-runtime-context")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: sensitivePermissionContextIntegrationConfig(createData, "123", "foo"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sym_integration.context", "settings.cloud", "aws"),
+					resource.TestCheckResourceAttr("sym_integration.context", "settings.region", "us-east-1"),
+					resource.TestCheckNoResourceAttr("sym_integration.context", "settings.external_id"),
+					resource.TestCheckResourceAttr("sym_integration.context", "sensitive_settings.external_id", utils.HashSensitiveValue("123")),
+					resource.TestCheckResourceAttr("sym_integration.context", "sensitive_settings.role_arn", utils.HashSensitiveValue(roleArnPrefix+"/foo")),
+				),
+			},
+			{
+				Config: sensitivePermissionContextIntegrationConfig(createData, "456", "foo"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("sym_integration.context", "sensitive_settings.external_id", utils.HashSensitiveValue("456")),
+					resource.TestCheckResourceAttr("sym_integration.context", "sensitive_settings.role_arn", utils.HashSensitiveValue(roleArnPrefix+"/foo")),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSymIntegration_pagerDuty(t *testing.T) {
 	createData := BuildTestData("pagerduty-integration")
 	updateData := BuildTestData("updated-pagerduty-integration")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: pagerDutyIntegrationConfig(createData, "PagerDuty", "pd-account"),
@@ -116,8 +146,8 @@ func TestAccSymIntegration_aptible(t *testing.T) {
 	updateData := BuildTestData("updated-aptible-integration")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: aptibleIntegrationConfig(createData, "Aptible", "aptible-account"),
@@ -150,8 +180,8 @@ func TestAccSymIntegration_okta(t *testing.T) {
 	updateData := BuildTestData("updated-okta-integration")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: oktaIntegrationConfig(createData, "Okta", "okta-account"),
@@ -185,8 +215,8 @@ func TestAccSymIntegration_custom(t *testing.T) {
 	secretIdsRegexp, _ := regexp.Compile("\\[\"[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\"]")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: customIntegrationConfig(createData, "Custom Integration", "external-id-1"),
@@ -282,6 +312,28 @@ resource "sym_secrets" "test" {
 	return sb.String()
 }
 
+func sensitivePermissionContextIntegrationConfig(data TestData, awsExternalId, awsArnSuffix string) string {
+	var sb strings.Builder
+
+	sb.WriteString(providerResource{org: data.OrgSlug}.String())
+	sb.WriteString(integrationResource{
+		terraformName: "context",
+		type_:         "permission_context",
+		name:          data.ResourceName,
+		externalId:    "5555555",
+		settings: map[string]string{
+			"cloud":  "aws",
+			"region": "us-east-1",
+		},
+		sensitiveSettings: map[string]string{
+			"external_id": awsExternalId,
+			"role_arn":    roleArnPrefix + "/" + awsArnSuffix,
+		},
+	}.String())
+
+	return sb.String()
+}
+
 func pagerDutyIntegrationConfig(data TestData, label, externalId string) string {
 	var sb strings.Builder
 
@@ -20,17 +20,20 @@ func LogDestination() *schema.Resource {
 		ReadContext:   readLogDestination,
 		UpdateContext: updateLogDestination,
 		DeleteContext: deleteLogDestination,
+		CustomizeDiff: validateOnPlan("LogDestination", logDestinationPatchFields, validateLogDestinationOnPlan),
 		Importer: &schema.ResourceImporter{
-			StateContext: getNameAndTypeImporter("log_destination"),
+			StateContext: getImporter("log_destination", logDestinationImportCandidates),
 		},
 	}
 }
 
 func LogDestinationSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"type":           utils.Required(schema.TypeString, "The type of the Log Destination."),
-		"integration_id": utils.Optional(schema.TypeString, "The ID for the Integration associated with this Log Destination."),
-		"settings":       utils.SettingsMap("A map of settings specific to this Log Destination."),
+		"type":                utils.Required(schema.TypeString, "The type of the Log Destination."),
+		"integration_id":      utils.Optional(schema.TypeString, "The ID for the Integration associated with this Log Destination."),
+		"settings":            utils.SettingsMap("A map of settings specific to this Log Destination."),
+		"sensitive_settings":  utils.SensitiveSettingsMap("this Log Destination"),
+		"deletion_protection": utils.DeletionProtection(),
 	}
 }
 
@@ -50,17 +53,41 @@ func validateLogDestination(diags diag.Diagnostics, ld *client.LogDestination) d
 	return diags
 }
 
-func createLogDestination(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+// logDestinationImportCandidates lists every LogDestination that may be imported as a sym_log_destination.
+var logDestinationImportCandidates = importCandidatesOf(
+	func(c *client.ApiClient) ([]client.LogDestination, error) { return c.LogDestination.List(nil) },
+	func(destination client.LogDestination) importCandidate {
+		return importCandidate{Id: destination.Id, Slug: destination.Slug, Qualifiers: []string{destination.Type}}
+	},
+)
+
+// buildLogDestination returns the LogDestination configured by the resource.
+func buildLogDestination(data resourceGetter) (client.LogDestination, diag.Diagnostics) {
+	settings, diags := getMergedSettings(data)
 
 	destination := client.LogDestination{
 		Type:          data.Get("type").(string),
 		IntegrationId: data.Get("integration_id").(string),
-		Settings:      getSettings(data),
+		Settings:      settings,
+	}
+
+	return destination, validateLogDestination(diags, &destination)
+}
+
+func validateLogDestinationOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	destination, diags := buildLogDestination(d)
+	if err := diagsError(diags); err != nil {
+		return err
 	}
+	destination.Id = d.Id()
+	return m.Client.LogDestination.Validate(destination)
+}
+
+func createLogDestination(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*providerMeta).Client
 
-	if diags = validateLogDestination(diags, &destination); diags.HasError() {
+	destination, diags := buildLogDestination(data)
+	if diags.HasError() {
 		return diags
 	}
 
@@ -69,6 +96,7 @@ func createLogDestination(_ context.Context, data *schema.ResourceData, meta int
 		diags = utils.DiagsCheckError(diags, err, "Unable to create LogDestination")
 	} else {
 		data.SetId(id)
+		diags = utils.DiagsCheckError(diags, setHashedSensitiveSettings(data), "Unable to set LogDestination sensitive_settings")
 	}
 	return diags
 }
@@ -79,21 +107,12 @@ func readLogDestination(_ context.Context, data *schema.ResourceData, meta inter
 		destination *client.LogDestination
 		err         error
 	)
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	idParts, parseErr := resourceIdToParts(id, "log_destination")
-	if parseErr == nil {
-		// If the ID was parsed as `TYPE:SLUG` successfully, perform a lookup using those values.
-		// This means we are in a `terraform import` scenario.
-		destination, err = c.LogDestination.Find(idParts.Slug, idParts.Subtype)
-	} else {
-		// If the ID could not be parsed as `TYPE:SLUG`, we are doing a normal read at apply-time.
-		destination, err = c.LogDestination.Read(id)
-	}
-
+	destination, err = c.LogDestination.Read(id)
 	if err != nil {
-		if isNotFoundError(err) {
+		if utils.IsNotFoundError(err) {
 			log.Println(notFoundWarning("LogDestination", id))
 			data.SetId("")
 			return nil
@@ -102,35 +121,44 @@ func readLogDestination(_ context.Context, data *schema.ResourceData, meta inter
 		return diags
 	}
 
-	// In the case of a normal read, ID will already be set and this is redundant.
-	// In the case of a `terraform import`, we need to set ID since it was previously TYPE:SLUG.
-	// This must happen below the error checking in case the lookup failed.
-	data.SetId(destination.Id)
-
 	diags = utils.DiagsCheckError(diags, data.Set("type", destination.Type), "Unable to read LogDestination type")
 	diags = utils.DiagsCheckError(diags, data.Set("integration_id", destination.IntegrationId), "Unable to read LogDestination integration_id")
-	diags = utils.DiagsCheckError(diags, data.Set("settings", destination.Settings), "Unable to read LogDestination settings")
+	diags = append(diags, setMergedSettings(data, destination.Settings)...)
+
+	diags = append(diags, setMetadata(data, "LogDestination", destination.Type, destination.Metadata)...)
 
 	return diags
 }
 
+// logDestinationPatchFields are the LogDestination fields sent when their attributes change.
+var logDestinationPatchFields = patchFields{
+	"type":           {"type"},
+	"integration_id": {"integration_id"},
+	"settings":       {"settings", "sensitive_settings"},
+}
+
 func updateLogDestination(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 
-	destination := client.LogDestination{
-		Id:            data.Id(),
-		Type:          data.Get("type").(string),
-		IntegrationId: data.Get("integration_id").(string),
-		Settings:      getSettings(data),
+	destination, diags := buildLogDestination(data)
+	if diags.HasError() {
+		return diags
 	}
+	destination.Id = data.Id()
 
-	if diags = validateLogDestination(diags, &destination); diags.HasError() {
+	patch, err := buildPatch(data, destination, logDestinationPatchFields)
+	if err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to update LogDestination"))
+		return diags
+	}
+	if len(patch) == 0 {
 		return diags
 	}
 
-	if _, err := c.LogDestination.Update(destination); err != nil {
+	if _, err := ifMatch(c, data).LogDestination.Patch(data.Id(), patch); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to update LogDestination"))
+	} else {
+		diags = utils.DiagsCheckError(diags, setHashedSensitiveSettings(data), "Unable to set LogDestination sensitive_settings")
 	}
 
 	return diags
@@ -138,10 +166,14 @@ func updateLogDestination(_ context.Context, data *schema.ResourceData, meta int
 
 func deleteLogDestination(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	if _, err := c.LogDestination.Delete(id); err != nil {
+	if diags = checkDeletion(data, c, "LogDestination", logDestinationDependents); diags.HasError() {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).LogDestination.Delete(id); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to delete LogDestination"))
 	}
 
@@ -11,8 +11,8 @@ func TestAccSymLogDestination_basic(t *testing.T) {
 	postData := BuildTestData("basic-log-destination-updated")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: logDestinationConfig(preData),
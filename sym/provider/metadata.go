@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+// Descriptions of the computed metadata attributes shared by every resource.
+const (
+	updatedAtDescription = "When this resource was last updated in Sym, by this provider, the Sym web app, or the Sym API."
+	updatedByDescription = "Who last updated this resource in Sym."
+	etagDescription      = "The version of this resource last read from Sym. Updates and deletes fail if the resource was changed in Sym " +
+		"since then, rather than overwriting the change."
+)
+
+// withMetadata adds the computed `updated_at`, `updated_by` and `etag` attributes to every resource in the
+// given map. Each resource's ReadContext must call setMetadata to populate them.
+func withMetadata(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, r := range resources {
+		r.Schema["updated_at"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: updatedAtDescription,
+		}
+		r.Schema["updated_by"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: updatedByDescription,
+		}
+		r.Schema["etag"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: etagDescription,
+		}
+		if r.CustomizeDiff != nil {
+			r.CustomizeDiff = customdiff.All(r.CustomizeDiff, customizeDiffMetadata)
+		} else {
+			r.CustomizeDiff = customizeDiffMetadata
+		}
+	}
+	return resources
+}
+
+// customizeDiffMetadata marks `updated_at`, `updated_by` and `etag` as unknown whenever the resource will be
+// updated, so that the change made by this apply is not reported as drift on the next refresh.
+func customizeDiffMetadata(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	for _, key := range d.GetChangedKeysPrefix("") {
+		if !strings.HasPrefix(key, "updated_") && key != "etag" {
+			for _, computed := range []string{"updated_at", "updated_by", "etag"} {
+				if err := d.SetNewComputed(computed); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// ifMatch returns a copy of the client whose Update and Delete requests fail if the entity was changed in Sym
+// since it was last read into the state. The `etag` in the state is used even when the plan has marked it as unknown.
+func ifMatch(c *client.ApiClient, data *schema.ResourceData) *client.ApiClient {
+	etag, _ := data.GetChange("etag")
+	return c.WithETag(etag.(string))
+}
+
+// setMetadata sets `updated_at`, `updated_by` and `etag` from the given entity metadata, warning if the entity
+// was changed in Sym since it was last read. No warning is emitted the first time the metadata is read,
+// or when it was cleared by an apply from this configuration (see customizeDiffMetadata).
+//
+// The `resource` and `name` provided will be used for warning message details.
+func setMetadata(data *schema.ResourceData, resource, name string, metadata client.Metadata) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	lastUpdatedAt := data.Get("updated_at").(string)
+	if lastUpdatedAt != "" && metadata.UpdatedAt != "" && metadata.UpdatedAt != lastUpdatedAt {
+		diags = append(diags, driftWarning(resource, name, metadata))
+	}
+
+	diags = utils.DiagsCheckError(diags, data.Set("updated_at", metadata.UpdatedAt), fmt.Sprintf("Unable to read %s updated_at", resource))
+	diags = utils.DiagsCheckError(diags, data.Set("updated_by", metadata.UpdatedBy), fmt.Sprintf("Unable to read %s updated_by", resource))
+	diags = utils.DiagsCheckError(diags, data.Set("etag", metadata.ETag), fmt.Sprintf("Unable to read %s etag", resource))
+
+	return diags
+}
+
+func driftWarning(resource, name string, metadata client.Metadata) diag.Diagnostic {
+	updatedBy := metadata.UpdatedBy
+	if updatedBy == "" {
+		updatedBy = "an unknown user"
+	}
+
+	subject := fmt.Sprintf("Sym %s", resource)
+	if name != "" {
+		subject = fmt.Sprintf("Sym %s %q", resource, name)
+	}
+
+	return utils.DiagWarning(
+		fmt.Sprintf("%s was changed outside of Terraform", subject),
+		fmt.Sprintf(
+			"The %s was updated by %s at %s, after it was last applied from this configuration. "+
+				"Differences in the plan for this resource may be caused by that change rather than by changes to your configuration.",
+			subject,
+			updatedBy,
+			metadata.UpdatedAt,
+		),
+	)
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+)
+
+func Test_setMetadata(t *testing.T) {
+	resources := withMetadata(map[string]*schema.Resource{"sym_target": Target()})
+
+	tests := []struct {
+		name          string
+		lastUpdatedAt string
+		metadata      client.Metadata
+		wantWarning   string
+	}{
+		{"first-read", "", client.Metadata{UpdatedAt: "2024-01-02T00:00:00Z", UpdatedBy: "jane@example.com"}, ""},
+		{"unchanged", "2024-01-02T00:00:00Z", client.Metadata{UpdatedAt: "2024-01-02T00:00:00Z", UpdatedBy: "jane@example.com", ETag: `"v1"`}, ""},
+		{"no-remote-metadata", "2024-01-02T00:00:00Z", client.Metadata{}, ""},
+		{
+			"changed",
+			"2024-01-02T00:00:00Z",
+			client.Metadata{UpdatedAt: "2024-01-03T00:00:00Z", UpdatedBy: "jane@example.com"},
+			`The Sym Target "prod" was updated by jane@example.com at 2024-01-03T00:00:00Z, after it was last applied from this configuration.`,
+		},
+		{
+			"changed-by-unknown-user",
+			"2024-01-02T00:00:00Z",
+			client.Metadata{UpdatedAt: "2024-01-03T00:00:00Z"},
+			`The Sym Target "prod" was updated by an unknown user at 2024-01-03T00:00:00Z`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := schema.TestResourceDataRaw(t, resources["sym_target"].Schema, map[string]interface{}{})
+			assert.NoError(t, data.Set("updated_at", tt.lastUpdatedAt))
+
+			diags := setMetadata(data, "Target", "prod", tt.metadata)
+
+			if tt.wantWarning == "" {
+				assert.Empty(t, diags)
+			} else {
+				assert.Len(t, diags, 1)
+				assert.Equal(t, diag.Warning, diags[0].Severity)
+				assert.Equal(t, `Sym Target "prod" was changed outside of Terraform`, diags[0].Summary)
+				assert.Contains(t, diags[0].Detail, tt.wantWarning)
+			}
+			assert.Equal(t, tt.metadata.UpdatedAt, data.Get("updated_at"))
+			assert.Equal(t, tt.metadata.UpdatedBy, data.Get("updated_by"))
+			assert.Equal(t, tt.metadata.ETag, data.Get("etag"))
+		})
+	}
+}
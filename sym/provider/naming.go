@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// labelPlaceholder is replaced with a resource's label in the `default_label_template` provider setting.
+const labelPlaceholder = "{label}"
+
+// naming applies the `default_name_prefix` and `default_label_template` provider settings to the names
+// and labels of resources.
+//
+// The Terraform state always stores the names and labels as configured, so that neither setting causes a
+// diff. The names and labels sent to Sym are exposed by the computed `full_name` attribute.
+type naming struct {
+	NamePrefix    string
+	LabelTemplate string
+}
+
+// fullName returns the name sent to Sym for the configured name. Names which already start with
+// the prefix are not prefixed again.
+func (n naming) fullName(name string) string {
+	if n.NamePrefix == "" || hasPrefixFold(name, n.NamePrefix) {
+		return name
+	}
+	return n.NamePrefix + name
+}
+
+// readName returns the name to store in the Terraform state for the name read from Sym. The current
+// name is kept if it still results in the name read from Sym, so that names configured with the prefix
+// (or with different casing, see utils.SuppressCaseSensitiveNamesDiffs) do not cause a diff.
+func (n naming) readName(current, full string) string {
+	if current != "" && strings.EqualFold(n.fullName(current), full) {
+		return current
+	}
+	if n.NamePrefix != "" && hasPrefixFold(full, n.NamePrefix) {
+		return full[len(n.NamePrefix):]
+	}
+	return full
+}
+
+// fullLabel returns the label sent to Sym for the configured label. Empty labels are left empty.
+func (n naming) fullLabel(label string) string {
+	if n.LabelTemplate == "" || label == "" {
+		return label
+	}
+	return strings.ReplaceAll(n.LabelTemplate, labelPlaceholder, label)
+}
+
+// readLabel returns the label to store in the Terraform state for the label read from Sym.
+func (n naming) readLabel(current, full string) string {
+	if n.fullLabel(current) == full {
+		return current
+	}
+	if n.LabelTemplate == "" {
+		return full
+	}
+
+	// Only the text around the first placeholder is stripped from the label.
+	parts := strings.SplitN(n.LabelTemplate, labelPlaceholder, 2)
+	if len(parts) != 2 {
+		return full
+	}
+	before, after := parts[0], parts[1]
+	if len(full) > len(before)+len(after) && strings.HasPrefix(full, before) && strings.HasSuffix(full, after) {
+		return full[len(before) : len(full)-len(after)]
+	}
+	return full
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+const fullNameDescription = "The name of this resource in Sym, including the provider's `default_name_prefix`."
+
+// fullNameSchema returns the schema for the computed `full_name` attribute of resources with a `name`.
+func fullNameSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: fullNameDescription,
+	}
+}
+
+// customizeDiffFullName plans the `full_name` attribute whenever the `name` attribute changes.
+func customizeDiffFullName(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("name") {
+		return nil
+	}
+
+	m, ok := meta.(*providerMeta)
+	if !ok || !d.NewValueKnown("name") {
+		return d.SetNewComputed("full_name")
+	}
+	return d.SetNew("full_name", m.Naming.fullName(d.Get("name").(string)))
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_naming_names(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefix       string
+		configured   string
+		wantFullName string
+	}{
+		{"no-prefix", "", "prod", "prod"},
+		{"prefix", "payments-", "prod", "payments-prod"},
+		{"already-prefixed", "payments-", "payments-prod", "payments-prod"},
+		{"already-prefixed-different-case", "payments-", "Payments-Prod", "Payments-Prod"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := naming{NamePrefix: tt.prefix}
+			assert.Equal(t, tt.wantFullName, n.fullName(tt.configured))
+
+			// Reading back the name sent to Sym must not cause a diff.
+			assert.Equal(t, tt.configured, n.readName(tt.configured, tt.wantFullName))
+		})
+	}
+}
+
+func Test_naming_readName(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		current string
+		full    string
+		want    string
+	}{
+		{"imported", "payments-", "", "payments-prod", "prod"},
+		{"imported-without-prefix", "payments-", "", "prod", "prod"},
+		{"lowercased-by-sym", "payments-", "Prod", "payments-prod", "Prod"},
+		{"renamed-outside-terraform", "payments-", "prod", "payments-staging", "staging"},
+		{"prefix-changed", "billing-", "prod", "payments-prod", "payments-prod"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := naming{NamePrefix: tt.prefix}
+			assert.Equal(t, tt.want, n.readName(tt.current, tt.full))
+		})
+	}
+}
+
+func Test_naming_labels(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		current   string
+		full      string
+		wantFull  string
+		wantState string
+	}{
+		{"no-template", "", "Prod", "Prod", "Prod", "Prod"},
+		{"template", "{label} (Payments)", "Prod", "Prod (Payments)", "Prod (Payments)", "Prod"},
+		{"empty-label", "{label} (Payments)", "", "", "", ""},
+		{"imported", "{label} (Payments)", "", "Prod (Payments)", "", "Prod"},
+		{"changed-outside-terraform", "{label} (Payments)", "Prod", "Production", "Prod (Payments)", "Production"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := naming{LabelTemplate: tt.template}
+			assert.Equal(t, tt.wantFull, n.fullLabel(tt.current))
+			assert.Equal(t, tt.wantState, n.readLabel(tt.current, tt.full))
+		})
+	}
+}
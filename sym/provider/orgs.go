@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+// orgsSchema returns the schema for the provider's `orgs` setting, which maps the slug of each
+// additional org to the environment variable storing its Sym Bot Token.
+func orgsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Description: "A map of additional Sym Org IDs to the environment variable storing each org's Sym Bot Token, which resources and data sources " +
+			"may be managed in by setting their `org` attribute. If an environment variable is empty, the default `SYM_JWT` or `symflow` configuration is used.",
+	}
+}
+
+const orgDescription = "The Sym Org ID to manage this resource in. Must be the provider's `org` or one of its `orgs`. Defaults to the provider's `org`."
+
+// withOrgs adds the `org` attribute to every resource or data source in the map, and wraps its CRUD
+// and import functions so that they are passed the client for that org. See providerMeta.forOrg.
+func withOrgs(resources map[string]*schema.Resource, isDataSource bool) map[string]*schema.Resource {
+	for _, r := range resources {
+		r.Schema["org"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    !isDataSource,
+			Description: orgDescription,
+		}
+
+		r.CreateContext = orgCrudFunc(r.CreateContext)
+		r.ReadContext = orgCrudFunc(r.ReadContext)
+		r.UpdateContext = orgCrudFunc(r.UpdateContext)
+		r.DeleteContext = orgCrudFunc(r.DeleteContext)
+		if r.Importer != nil && r.Importer.StateContext != nil {
+			r.Importer.StateContext = orgImporter(r.Importer.StateContext)
+		}
+	}
+	return resources
+}
+
+func orgCrudFunc(f crudFunc) crudFunc {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		m, err := meta.(*providerMeta).forOrg(data.Get("org").(string))
+		if err != nil {
+			return utils.DiagsFromError(err, "Invalid org")
+		}
+		return f(ctx, data, m)
+	}
+}
+
+// orgImporter wraps a ResourceImporter so that resources may be imported into another org by suffixing
+// the identifier with `@ORG`, e.g. `slack:my-integration@sandbox`.
+func orgImporter(f schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		m := meta.(*providerMeta)
+
+		if id, org := m.splitImportOrg(data.Id()); org != "" {
+			data.SetId(id)
+			if err := data.Set("org", org); err != nil {
+				return nil, err
+			}
+		}
+
+		orgMeta, err := m.forOrg(data.Get("org").(string))
+		if err != nil {
+			return nil, err
+		}
+		return f(ctx, data, orgMeta)
+	}
+}
+
+// splitImportOrg splits the `@ORG` suffix from an import identifier, if ORG is one of the provider's orgs.
+// See orgImporter.
+func (m *providerMeta) splitImportOrg(identifier string) (id, org string) {
+	if i := strings.LastIndex(identifier, "@"); i != -1 && m.hasOrg(identifier[i+1:]) {
+		return identifier[:i], identifier[i+1:]
+	}
+	return identifier, ""
+}
+
+// hasOrg returns true if the org is the provider's `org` or one of its `orgs`.
+func (m *providerMeta) hasOrg(org string) bool {
+	_, ok := m.OrgClients[org]
+	return org == m.Org || ok
+}
+
+// forOrg returns a copy of the providerMeta whose client manages the given org. An empty org is the
+// provider's default `org`.
+func (m *providerMeta) forOrg(org string) (*providerMeta, error) {
+	if org == "" || org == m.Org {
+		return m, nil
+	}
+
+	c, ok := m.OrgClients[org]
+	if !ok {
+		return nil, fmt.Errorf("the org %q is not the provider's org (%q), and is not configured in the provider's orgs", org, m.Org)
+	}
+
+	copied := *m
+	copied.Client = c
+	return &copied, nil
+}
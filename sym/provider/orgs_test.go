@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+)
+
+func Test_providerMeta_forOrg(t *testing.T) {
+	defaultClient := &client.ApiClient{}
+	sandboxClient := &client.ApiClient{}
+	m := &providerMeta{
+		Client:     defaultClient,
+		Org:        "prod",
+		OrgClients: map[string]*client.ApiClient{"sandbox": sandboxClient},
+	}
+
+	tests := []struct {
+		name       string
+		org        string
+		wantClient *client.ApiClient
+		wantErr    bool
+	}{
+		{"unset", "", defaultClient, false},
+		{"default-org", "prod", defaultClient, false},
+		{"additional-org", "sandbox", sandboxClient, false},
+		{"unknown-org", "staging", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.forOrg(tt.org)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, `the org "staging" is not the provider's org ("prod")`)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Same(t, tt.wantClient, got.Client)
+		})
+	}
+}
+
+func Test_orgImporter(t *testing.T) {
+	defaultClient := &client.ApiClient{}
+	sandboxClient := &client.ApiClient{}
+	m := &providerMeta{
+		Client:     defaultClient,
+		Org:        "prod",
+		OrgClients: map[string]*client.ApiClient{"sandbox": sandboxClient},
+	}
+	resource := withOrgs(map[string]*schema.Resource{"sym_integration": Integration()}, false)["sym_integration"]
+
+	tests := []struct {
+		name       string
+		importId   string
+		wantId     string
+		wantOrg    string
+		wantClient *client.ApiClient
+	}{
+		{"default-org", "slack:my-integration", "slack:my-integration", "", defaultClient},
+		{"additional-org", "slack:my-integration@sandbox", "slack:my-integration", "sandbox", sandboxClient},
+		{"unknown-org-is-part-of-identifier", "slack:me@example.com", "slack:me@example.com", "", defaultClient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotClient *client.ApiClient
+			importer := orgImporter(func(_ context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				gotClient = meta.(*providerMeta).Client
+				return []*schema.ResourceData{data}, nil
+			})
+
+			data := resource.Data(nil)
+			data.SetId(tt.importId)
+
+			_, err := importer(context.Background(), data, m)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantId, data.Id())
+			assert.Equal(t, tt.wantOrg, data.Get("org"))
+			assert.Same(t, tt.wantClient, gotClient)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+)
+
+// patchFields maps each API field of an entity to the Terraform attributes its value is built from.
+type patchFields map[string][]string
+
+// buildPatch returns the API fields of the entity whose attributes have changed, so that an update
+// does not resend unchanged fields (such as large implementations) or overwrite fields that are
+// managed by Sym rather than this provider.
+//
+// Each field is encoded exactly as it would be in a full update of the entity. A changed field which is
+// omitted from the entity's JSON, e.g. an `omitempty` field which has been cleared, is sent as null.
+func buildPatch(data *schema.ResourceData, entity interface{}, fields patchFields) (client.Patch, error) {
+	return patchOf(entity, fields, data.HasChanges)
+}
+
+// patchOf returns the API fields of the entity for which hasChanges reports that an attribute has changed.
+// See buildPatch.
+func patchOf(entity interface{}, fields patchFields, hasChanges func(attributes ...string) bool) (client.Patch, error) {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode numbers as json.Number so that they are re-encoded exactly.
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	var encoded map[string]interface{}
+	if err := decoder.Decode(&encoded); err != nil {
+		return nil, err
+	}
+
+	patch := client.Patch{}
+	for field, attributes := range fields {
+		if hasChanges(attributes...) {
+			patch[field] = encoded[field]
+		}
+	}
+	return patch, nil
+}
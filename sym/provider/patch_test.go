@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	fwschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+func Test_buildPatch(t *testing.T) {
+	resourceSchema := schema.InternalMap(Target().Schema)
+	state := &terraform.InstanceState{
+		ID: "target-id",
+		Attributes: map[string]string{
+			"id":                "target-id",
+			"type":              "okta_group",
+			"name":              "prod",
+			"full_name":         "prod",
+			"label":             "Prod",
+			"settings.%":        "1",
+			"settings.group_id": "00g123",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		changes map[string]interface{}
+		want    client.Patch
+	}{
+		{"no-changes", nil, client.Patch{}},
+		{"label", map[string]interface{}{"label": "Production"}, client.Patch{"label": "Production"}},
+		{"cleared-label", map[string]interface{}{"label": ""}, client.Patch{"label": nil}},
+		{
+			"settings",
+			map[string]interface{}{"settings": map[string]interface{}{"group_id": "00g456"}},
+			client.Patch{"settings": map[string]interface{}{"group_id": "00g456"}},
+		},
+		{
+			"several",
+			map[string]interface{}{"type": "aws_sso_permission_set", "label": "Production"},
+			client.Patch{"type": "aws_sso_permission_set", "label": "Production"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{
+				"type":     "okta_group",
+				"name":     "prod",
+				"label":    "Prod",
+				"settings": map[string]interface{}{"group_id": "00g123"},
+			}
+			for k, v := range tt.changes {
+				config[k] = v
+			}
+
+			diff, err := resourceSchema.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), nil, nil, false)
+			assert.NoError(t, err)
+			data, err := resourceSchema.Data(state, diff)
+			assert.NoError(t, err)
+
+			target := client.Target{
+				Id:       data.Id(),
+				Type:     data.Get("type").(string),
+				Name:     data.Get("name").(string),
+				Label:    data.Get("label").(string),
+				Settings: getSettings(data),
+			}
+
+			patch, err := buildPatch(data, target, targetPatchFields)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, patch)
+		})
+	}
+}
+
+func Test_patchFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]bool
+		entity     interface{}
+		fields     patchFields
+
+		// unmanaged are the API fields which are managed by Sym rather than this provider.
+		unmanaged []string
+	}{
+		{"sym_bot", sdkAttributes(Bot()), client.Bot{}, botPatchFields, nil},
+		{"sym_environment", sdkAttributes(Environment()), client.Environment{}, environmentPatchFields, nil},
+		{"sym_error_logger", sdkAttributes(ErrorLogger()), client.ErrorLogger{}, errorLoggerPatchFields, []string{"slug"}},
+		{"sym_flow", frameworkAttributes(flowSchema()), client.Flow{}, flowPatchFields, nil},
+		{"sym_flows_filter", sdkAttributes(FlowsFilter()), client.FlowsFilter{}, flowsFilterPatchFields, nil},
+		{"sym_integration", sdkAttributes(Integration()), client.Integration{}, integrationPatchFields, nil},
+		{"sym_log_destination", sdkAttributes(LogDestination()), client.LogDestination{}, logDestinationPatchFields, []string{"slug"}},
+		{"sym_runtime", sdkAttributes(Runtime()), client.Runtime{}, runtimePatchFields, nil},
+		{"sym_secret", sdkAttributes(Secret()), client.Secret{}, secretPatchFields, nil},
+		{"sym_secrets", sdkAttributes(Secrets()), client.Secrets{}, secretsPatchFields, nil},
+		{"sym_strategy", sdkAttributes(Strategy()), client.Strategy{}, strategyPatchFields, nil},
+		{"sym_target", sdkAttributes(Target()), client.Target{}, targetPatchFields, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonFields := make(map[string]bool)
+			entityType := reflect.TypeOf(tt.entity)
+			for i := 0; i < entityType.NumField(); i++ {
+				name := strings.Split(entityType.Field(i).Tag.Get("json"), ",")[0]
+				jsonFields[name] = true
+			}
+
+			for field, attributes := range tt.fields {
+				assert.True(t, jsonFields[field], "%s is not a field of the API entity", field)
+				for _, attribute := range attributes {
+					assert.True(t, tt.attributes[attribute], "%s is not an attribute of %s", attribute, tt.name)
+				}
+			}
+
+			// Every API field managed by this provider must be sent when it changes.
+			for field := range jsonFields {
+				if field != "" && field != "id" && !utils.ContainsString(tt.unmanaged, field) {
+					assert.Contains(t, tt.fields, field)
+				}
+			}
+		})
+	}
+}
+
+// sdkAttributes returns the names of the top-level attributes of an SDK resource.
+func sdkAttributes(r *schema.Resource) map[string]bool {
+	attributes := make(map[string]bool, len(r.Schema))
+	for name := range r.Schema {
+		attributes[name] = true
+	}
+	return attributes
+}
+
+// frameworkAttributes returns the names of the top-level attributes and blocks of a framework resource.
+func frameworkAttributes(s fwschema.Schema) map[string]bool {
+	attributes := make(map[string]bool, len(s.Attributes)+len(s.Blocks))
+	for name := range s.Attributes {
+		attributes[name] = true
+	}
+	for name := range s.Blocks {
+		attributes[name] = true
+	}
+	return attributes
+}
@@ -2,11 +2,17 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/tracing"
 	"github.com/symopsio/terraform-provider-sym/sym/utils"
 )
 
@@ -24,9 +30,60 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "Environment variable storing your Sym Bot Token",
 			},
+			"hash_implementations": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "If true, the Terraform state stores a SHA-256 hash of each `sym_flow` and `sym_flows_filter` implementation and " +
+					"each `on_change` implementation instead of the full source code. Existing state is migrated on the next refresh.",
+			},
+			"workspace_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the Terraform workspace managing this configuration, e.g. `terraform.workspace`. Recorded on every Sym entity created or updated by the provider.",
+			},
+			"repo_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL of the repository containing this configuration. Recorded on every Sym entity created or updated by the provider.",
+			},
+			"lock_ui_edits": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, the Sym API will reject changes made outside of Terraform (e.g. in the Sym web app) to every Sym entity created or updated by the provider.",
+			},
+			"default_name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A prefix added to the `name` of every resource that has one, e.g. `payments-`. Names which already start with the prefix are not changed. The resulting names are exposed by each resource's `full_name` attribute.",
+			},
+			"default_label_template": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "A template applied to the `label` of every resource that has one, where `" + labelPlaceholder + "` is replaced with the configured label, " +
+					"e.g. `" + labelPlaceholder + " (Payments)`. Resources without a label are not changed.",
+			},
+			"read_cache": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "If true, the first read of a Sym entity lists every entity of its type, and the rest are read from that list, " +
+					"which greatly speeds up refreshing large configurations. Entities read from a list have no `etag`, " +
+					"so their updates are not checked for concurrent changes.",
+			},
+			"validate_on_plan": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "If true, each resource that will be created or updated is validated by the Sym API during `terraform plan`, " +
+					"so that invalid configuration is reported before any changes are applied. Resources whose attributes are not known " +
+					"until apply are not validated.",
+			},
+			"orgs":    orgsSchema(),
+			"tracing": tracingSchema(),
 		},
-		ResourcesMap: map[string]*schema.Resource{
-			"sym_flow":            Flow(),
+		ResourcesMap: traceResources(withMetadata(withOrgs(map[string]*schema.Resource{
 			"sym_strategy":        Strategy(),
 			"sym_target":          Target(),
 			"sym_secret":          Secret(),
@@ -37,34 +94,178 @@ func Provider() *schema.Provider {
 			"sym_error_logger":    ErrorLogger(),
 			"sym_log_destination": LogDestination(),
 			"sym_flows_filter":    FlowsFilter(),
-		},
-		DataSourcesMap: map[string]*schema.Resource{
+			"sym_bot":             Bot(),
+		}, false))),
+		DataSourcesMap: traceResources(withOrgs(map[string]*schema.Resource{
 			"sym_integration": DataSourceIntegration(),
 			"sym_runtime":     DataSourceRuntime(),
 			"sym_environment": DataSourceEnvironment(),
 			"sym_secrets":     DataSourceSecrets(),
-		},
+		}, true)),
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+// NewProviderServer returns the server run by main, which serves the resources of both the SDK provider and
+// the framework provider (see frameworkProvider), so that resources may be ported to the framework one at a time.
+func NewProviderServer(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	muxServer, err := tf5muxserver.NewMuxServer(ctx,
+		func() tfprotov5.ProviderServer { return implementationDiffServer{Provider().GRPCProvider()} },
+		func() tfprotov5.ProviderServer {
+			return hashedImplementationServer{providerserver.NewProtocol5(newFrameworkProvider())()}
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	orgs := make(map[string]string)
+	for org, jwtEnvVar := range d.Get("orgs").(map[string]interface{}) {
+		orgs[org] = jwtEnvVar.(string)
+	}
+
+	m, diags := configure(ctx, providerConfig{
+		Org:                  d.Get("org").(string),
+		JWTEnvVar:            d.Get("jwt_env_var").(string),
+		Orgs:                 orgs,
+		HashImplementations:  d.Get("hash_implementations").(bool),
+		WorkspaceName:        d.Get("workspace_name").(string),
+		RepoURL:              d.Get("repo_url").(string),
+		LockUIEdits:          d.Get("lock_ui_edits").(bool),
+		DefaultNamePrefix:    d.Get("default_name_prefix").(string),
+		DefaultLabelTemplate: d.Get("default_label_template").(string),
+		ReadCache:            d.Get("read_cache").(bool),
+		ValidateOnPlan:       d.Get("validate_on_plan").(bool),
+		Tracing:              getTracingConfig(d),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+	return m, diags
+}
+
+// providerConfig is the provider configuration, as read by either the SDK provider or the framework provider.
+type providerConfig struct {
+	Org                  string
+	JWTEnvVar            string
+	Orgs                 map[string]string
+	HashImplementations  bool
+	WorkspaceName        string
+	RepoURL              string
+	LockUIEdits          bool
+	DefaultNamePrefix    string
+	DefaultLabelTemplate string
+	ReadCache            bool
+	ValidateOnPlan       bool
+	Tracing              tracing.Config
+}
+
+// configure validates the provider configuration and returns the providerMeta passed to every resource
+// and data source.
+func configure(ctx context.Context, cfg providerConfig) (*providerMeta, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	terraformOrg := d.Get("org").(string)
-	terraformJwtEnvVar := d.Get("jwt_env_var").(string)
 
-	cfg, err := utils.GetDefaultConfig(terraformJwtEnvVar)
-	if err != nil {
-		diags = append(diags, utils.DiagFromError(err, "Validation failed"))
+	if err := tracing.Setup(ctx, cfg.Tracing); err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to configure tracing"))
+		return nil, diags
+	}
+
+	if cfg.DefaultLabelTemplate != "" && !strings.Contains(cfg.DefaultLabelTemplate, labelPlaceholder) {
+		diags = append(diags, utils.DiagFromError(
+			fmt.Errorf("default_label_template must contain the %s placeholder", labelPlaceholder),
+			"Invalid default_label_template",
+		))
 		return nil, diags
 	}
 
-	err = cfg.ValidateOrg(terraformOrg)
+	annotations := client.Annotations{
+		WorkspaceName: cfg.WorkspaceName,
+		RepoURL:       cfg.RepoURL,
+		LockUIEdits:   cfg.LockUIEdits,
+	}
+
+	defaultClient, err := newOrgClient(cfg.Org, cfg.JWTEnvVar, annotations, cfg.ReadCache)
 	if err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Validation failed"))
 		return nil, diags
 	}
 
+	// Each additional org has its own client, and is validated separately.
+	orgClients := make(map[string]*client.ApiClient)
+	for org, jwtEnvVar := range cfg.Orgs {
+		if org == cfg.Org {
+			continue
+		}
+		if orgClients[org], err = newOrgClient(org, jwtEnvVar, annotations, cfg.ReadCache); err != nil {
+			diags = append(diags, utils.DiagFromError(err, fmt.Sprintf("Validation failed for org %s", org)))
+			return nil, diags
+		}
+	}
+
+	m := &providerMeta{
+		Client:              defaultClient,
+		Org:                 cfg.Org,
+		OrgClients:          orgClients,
+		HashImplementations: cfg.HashImplementations,
+		ValidateOnPlan:      cfg.ValidateOnPlan,
+		Naming: naming{
+			NamePrefix:    cfg.DefaultNamePrefix,
+			LabelTemplate: cfg.DefaultLabelTemplate,
+		},
+	}
+	return m, diags
+}
+
+// newOrgClient returns a client for the org, using the Sym Bot Token in the given environment variable,
+// or the default `SYM_JWT` or `symflow` configuration if it is empty.
+func newOrgClient(org, jwtEnvVar string, annotations client.Annotations, readCache bool) (*client.ApiClient, error) {
+	cfg, err := utils.GetDefaultConfig(jwtEnvVar)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.ValidateOrg(org); err != nil {
+		return nil, err
+	}
+
 	c := client.New(cfg.AuthToken.AccessToken)
-	return c, diags
+	if readCache {
+		c = c.WithReadCache()
+	}
+	return c.WithAnnotations(annotations), nil
+}
+
+// providerMeta is passed as the meta argument to every resource and data source CRUD function.
+type providerMeta struct {
+	// Client is the client for the org of the resource or data source being managed. See forOrg.
+	Client *client.ApiClient
+
+	// Org is the provider's default `org`.
+	Org string
+
+	// OrgClients are the clients for each of the provider's additional `orgs`.
+	OrgClients map[string]*client.ApiClient
+
+	// HashImplementations indicates that implementations should be stored in the Terraform state as hashes.
+	HashImplementations bool
+
+	// ValidateOnPlan indicates that resources should be validated by the Sym API during plan. See validateOnPlan.
+	ValidateOnPlan bool
+
+	// Naming applies the provider's default name prefix and label template.
+	Naming naming
+}
+
+// withContext returns a copy of the providerMeta whose client makes requests with the given context.
+func (m *providerMeta) withContext(ctx context.Context) *providerMeta {
+	copied := *m
+	copied.Client = m.Client.WithContext(ctx)
+	copied.OrgClients = make(map[string]*client.ApiClient, len(m.OrgClients))
+	for org, c := range m.OrgClients {
+		copied.OrgClients[org] = c.WithContext(ctx)
+	}
+	return &copied
 }
@@ -1,22 +1,24 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-var testAccProviderFactories map[string]func() (*schema.Provider, error)
-var testAccProvider *schema.Provider
-
-func init() {
-	testAccProvider = Provider()
-	testAccProviderFactories = map[string]func() (*schema.Provider, error){
-		"sym": func() (*schema.Provider, error) {
-			return testAccProvider, nil
-		},
-	}
+// testAccProtoV5ProviderFactories serve the SDK provider and the framework provider together, as main does.
+var testAccProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"sym": func() (tfprotov5.ProviderServer, error) {
+		providerServer, err := NewProviderServer(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		return providerServer(), nil
+	},
 }
 
 // testAccPreCheck should be used as a PreCheck to ensure the current
@@ -36,3 +38,16 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("Acceptance tests must not point to production")
 	}
 }
+
+func TestNewProviderServer(t *testing.T) {
+	providerServer, err := NewProviderServer(context.Background())
+	require.NoError(t, err)
+
+	// The SDK provider and the framework provider must have identical provider schemas, and serve different resources.
+	resp, err := providerServer().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics)
+	assert.Contains(t, resp.ResourceSchemas, "sym_flow")
+	assert.Contains(t, resp.ResourceSchemas, "sym_target")
+	assert.Contains(t, resp.Functions, "parse_srn")
+}
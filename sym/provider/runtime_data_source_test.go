@@ -11,8 +11,8 @@ func TestAccSymRuntimeData_basic(t *testing.T) {
 	runtimeData := BuildTestData("basic-runtime-data-source")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: runtimeDataConfig(runtimeData),
@@ -4,8 +4,8 @@ import (
 	"context"
 	"log"
 
-	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/symopsio/terraform-provider-sym/sym/client"
@@ -19,24 +19,50 @@ func Runtime() *schema.Resource {
 		ReadContext:   readRuntime,
 		UpdateContext: updateRuntime,
 		DeleteContext: deleteRuntime,
+		CustomizeDiff: customdiff.All(
+			customizeDiffFullName,
+			validateOnPlan("Runtime", runtimePatchFields, validateRuntimeOnPlan),
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: getSlugImporter("runtime"),
+			StateContext: getImporter("runtime", runtimeImportCandidates),
 		},
 		Schema: map[string]*schema.Schema{
-			"name":       utils.RequiredCaseInsensitiveString("A unique identifier for this Sym Runtime."),
-			"label":      utils.Optional(schema.TypeString, "An optional label for the Runtime."),
-			"context_id": utils.Optional(schema.TypeString, "The ID of the Runtime Permission Context integration associated with this Runtime."),
+			"name":                utils.RequiredCaseInsensitiveString("A unique identifier for this Sym Runtime."),
+			"full_name":           fullNameSchema(),
+			"label":               utils.Optional(schema.TypeString, "An optional label for the Runtime."),
+			"context_id":          utils.Optional(schema.TypeString, "The ID of the Runtime Permission Context integration associated with this Runtime."),
+			"deletion_protection": utils.DeletionProtection(),
 		},
 	}
 }
 
-func createRuntime(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client.ApiClient)
-	runtime := client.Runtime{
-		Name:      data.Get("name").(string),
-		Label:     data.Get("label").(string),
+// runtimeImportCandidates lists every Runtime that may be imported as a sym_runtime.
+var runtimeImportCandidates = importCandidatesOf(
+	func(c *client.ApiClient) ([]client.Runtime, error) { return c.Runtime.List(nil) },
+	func(runtime client.Runtime) importCandidate {
+		return importCandidate{Id: runtime.Id, Slug: runtime.Name}
+	},
+)
+
+// buildRuntime returns the Runtime configured by the resource.
+func buildRuntime(data resourceGetter, m *providerMeta) client.Runtime {
+	return client.Runtime{
+		Name:      m.Naming.fullName(data.Get("name").(string)),
+		Label:     m.Naming.fullLabel(data.Get("label").(string)),
 		ContextId: data.Get("context_id").(string),
 	}
+}
+
+func validateRuntimeOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	runtime := buildRuntime(d, m)
+	runtime.Id = d.Id()
+	return m.Client.Runtime.Validate(runtime)
+}
+
+func createRuntime(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	c := m.Client
+	runtime := buildRuntime(data, m)
 
 	id, err := c.Runtime.Create(runtime)
 	if err != nil {
@@ -53,20 +79,13 @@ func readRuntime(_ context.Context, data *schema.ResourceData, meta interface{})
 		runtime *client.Runtime
 		err     error
 	)
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 	id := data.Id()
 
-	if _, parseErr := uuid.ParseUUID(id); parseErr == nil {
-		// If the ID is a UUID, look up the Runtime directly.
-		runtime, err = c.Runtime.Read(id)
-	} else {
-		// Otherwise, we are probably in the context of a `terraform import` and should attempt
-		// to look up the Runtime by slug.
-		runtime, err = c.Runtime.Find(id)
-	}
-
+	runtime, err = c.Runtime.Read(id)
 	if err != nil {
-		if isNotFoundError(err) {
+		if utils.IsNotFoundError(err) {
 			log.Println(notFoundWarning("Runtime", id))
 			data.SetId("")
 			return nil
@@ -75,30 +94,41 @@ func readRuntime(_ context.Context, data *schema.ResourceData, meta interface{})
 		return diags
 	}
 
-	// In the case of a normal read, ID will already be set and this is redundant.
-	// In the case of a `terraform import`, we need to set ID since it was previously TYPE:SLUG.
-	// This must happen below the error checking in case the lookup failed.
-	data.SetId(runtime.Id)
-
-	diags = utils.DiagsCheckError(diags, data.Set("name", runtime.Name), "Unable to read Runtime name")
-	diags = utils.DiagsCheckError(diags, data.Set("label", runtime.Label), "Unable to read Runtime label")
+	diags = utils.DiagsCheckError(diags, data.Set("name", m.Naming.readName(data.Get("name").(string), runtime.Name)), "Unable to read Runtime name")
+	diags = utils.DiagsCheckError(diags, data.Set("full_name", runtime.Name), "Unable to read Runtime full_name")
+	diags = utils.DiagsCheckError(diags, data.Set("label", m.Naming.readLabel(data.Get("label").(string), runtime.Label)), "Unable to read Runtime label")
 	diags = utils.DiagsCheckError(diags, data.Set("context_id", runtime.ContextId), "Unable to read Runtime context_id")
 
+	diags = append(diags, setMetadata(data, "Runtime", runtime.Name, runtime.Metadata)...)
+
 	return diags
 }
 
+// runtimePatchFields are the Runtime fields sent when their attributes change.
+var runtimePatchFields = patchFields{
+	"slug":       {"name", "full_name"},
+	"label":      {"label"},
+	"context_id": {"context_id"},
+}
+
 func updateRuntime(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 
-	runtime := client.Runtime{
-		Id:        data.Id(),
-		Name:      data.Get("name").(string),
-		Label:     data.Get("label").(string),
-		ContextId: data.Get("context_id").(string),
+	runtime := buildRuntime(data, m)
+	runtime.Id = data.Id()
+
+	patch, err := buildPatch(data, runtime, runtimePatchFields)
+	if err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to update Runtime"))
+		return diags
+	}
+	if len(patch) == 0 {
+		return diags
 	}
 
-	if _, err := c.Runtime.Update(runtime); err != nil {
+	if _, err := ifMatch(c, data).Runtime.Patch(data.Id(), patch); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to update Runtime"))
 	}
 
@@ -107,10 +137,14 @@ func updateRuntime(_ context.Context, data *schema.ResourceData, meta interface{
 
 func deleteRuntime(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	if _, err := c.Runtime.Delete(id); err != nil {
+	if diags = checkDeletion(data, c, "Runtime", runtimeDependents); diags.HasError() {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).Runtime.Delete(id); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to delete Runtime"))
 	}
 
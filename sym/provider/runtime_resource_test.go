@@ -12,8 +12,8 @@ func TestAccSymRuntime_basic(t *testing.T) {
 	updateRuntimeConfig := runtimeConfig(runtimeData, "Updated Test Runtime", "sym_integration.runtime_test_context.id")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: createRuntimeConfig,
@@ -41,8 +41,8 @@ func TestAccSymRuntime_withoutContext(t *testing.T) {
 	updateRuntimeConfig := runtimeConfig(runtimeData, "Updated Test Runtime", "")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: createRuntimeConfig,
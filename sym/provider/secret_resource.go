@@ -4,7 +4,7 @@ import (
 	"context"
 	"log"
 
-	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -20,8 +20,9 @@ func Secret() *schema.Resource {
 		ReadContext:   readSecret,
 		UpdateContext: updateSecret,
 		DeleteContext: deleteSecret,
+		CustomizeDiff: validateOnPlan("Secret", secretPatchFields, validateSecretOnPlan),
 		Importer: &schema.ResourceImporter{
-			StateContext: getSlugImporter("secret"),
+			StateContext: getImporter("secret", secretImportCandidates),
 		},
 	}
 }
@@ -32,18 +33,81 @@ func secretSchema() map[string]*schema.Schema {
 		"source_id": utils.Required(schema.TypeString, "The ID of the `sym_secrets` resource which serves as the source for this Secret."),
 		"label":     utils.Optional(schema.TypeString, "An optional label for the Secret."),
 		"settings":  utils.SettingsMap("Used to specify the key if the secret is stored as a JSON blob. E.g. settings = { json_key = \"secret_key\" }"),
+		"value_wo": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			WriteOnly:    true,
+			RequiredWith: []string{"value_wo_version"},
+			Description:  "The value of the Secret, which the provider stores in the Secret's source through the Sym API. It is write-only, so it is never stored in the Terraform plan or state, and requires Terraform 1.11 or later. The value is only sent when the Secret is created or `value_wo_version` changes.",
+		},
+		"value_wo_version": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			RequiredWith: []string{"value_wo"},
+			Description:  "A version of `value_wo` which must be changed, e.g. incremented, to send a new value to the Secret's source.",
+		},
+		"deletion_protection": utils.DeletionProtection(),
 	}
 }
 
-func createSecret(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client.ApiClient)
+// setSecretValue stores the configured `value_wo`, if any, in the Secret's source.
+func setSecretValue(data *schema.ResourceData, c *client.ApiClient) diag.Diagnostics {
+	value, diags := data.GetRawConfigAt(cty.GetAttrPath("value_wo"))
+	if diags.HasError() || value.IsNull() {
+		return diags
+	}
+
+	if err := c.Secret.SetValue(data.Id(), value.AsString()); err != nil {
+		return utils.DiagsFromError(err, "Unable to set Secret value")
+	}
+	return nil
+}
+
+// secretImportCandidates lists every Secret that may be imported as a sym_secret. Secrets are identified by
+// their source and path, as `SOURCE:PATH`, where SOURCE is the slug or ID of the Secret's `sym_secrets` source.
+func secretImportCandidates(c *client.ApiClient) ([]importCandidate, error) {
+	sources, err := c.Secrets.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	sourceSlugs := make(map[string]string, len(sources))
+	for _, source := range sources {
+		sourceSlugs[source.Id] = source.Name
+	}
+
+	return importCandidatesOf(
+		func(c *client.ApiClient) ([]client.Secret, error) { return c.Secret.List(nil) },
+		func(secret client.Secret) importCandidate {
+			qualifiers := []string{secret.SourceId}
+			if slug, ok := sourceSlugs[secret.SourceId]; ok {
+				qualifiers = []string{slug, secret.SourceId}
+			}
+			return importCandidate{Id: secret.Id, Slug: secret.Path, Qualifiers: qualifiers}
+		},
+	)(c)
+}
 
-	secret := client.Secret{
+// buildSecret returns the Secret configured by the resource.
+func buildSecret(data resourceGetter, m *providerMeta) client.Secret {
+	return client.Secret{
 		Path:     data.Get("path").(string),
 		SourceId: data.Get("source_id").(string),
-		Label:    data.Get("label").(string),
+		Label:    m.Naming.fullLabel(data.Get("label").(string)),
 		Settings: getSettings(data),
 	}
+}
+
+func validateSecretOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	secret := buildSecret(d, m)
+	secret.Id = d.Id()
+	return m.Client.Secret.Validate(secret)
+}
+
+func createSecret(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	c := m.Client
+
+	secret := buildSecret(data, m)
 
 	id, err := c.Secret.Create(secret)
 	if err != nil {
@@ -51,7 +115,8 @@ func createSecret(_ context.Context, data *schema.ResourceData, meta interface{}
 	}
 
 	data.SetId(id)
-	return nil
+	// If the value cannot be set, the error taints the Secret so that it is recreated on the next apply.
+	return setSecretValue(data, c)
 }
 
 func readSecret(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -60,20 +125,13 @@ func readSecret(_ context.Context, data *schema.ResourceData, meta interface{})
 		secret *client.Secret
 		err    error
 	)
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 	id := data.Id()
 
-	if _, parseErr := uuid.ParseUUID(id); parseErr == nil {
-		// If the ID is a UUID, look up the Secret directly.
-		secret, err = c.Secret.Read(id)
-	} else {
-		// Otherwise, we are probably in the context of a `terraform import` and should attempt
-		// to look up the Secret by slug.
-		secret, err = c.Secret.Find(id)
-	}
-
+	secret, err = c.Secret.Read(id)
 	if err != nil {
-		if isNotFoundError(err) {
+		if utils.IsNotFoundError(err) {
 			log.Println(notFoundWarning("Secret", id))
 			data.SetId("")
 			return nil
@@ -82,32 +140,49 @@ func readSecret(_ context.Context, data *schema.ResourceData, meta interface{})
 		return diags
 	}
 
-	// In the case of a normal read, ID will already be set and this is redundant.
-	// In the case of a `terraform import`, we need to set ID since it was previously TYPE:SLUG.
-	// This must happen below the error checking in case the lookup failed.
-	data.SetId(secret.Id)
-
 	diags = utils.DiagsCheckError(diags, data.Set("path", secret.Path), "Unable to read Secret path")
 	diags = utils.DiagsCheckError(diags, data.Set("source_id", secret.SourceId), "Unable to read Secret source_id")
-	diags = utils.DiagsCheckError(diags, data.Set("label", secret.Label), "Unable to read Secret label")
+	diags = utils.DiagsCheckError(diags, data.Set("label", m.Naming.readLabel(data.Get("label").(string), secret.Label)), "Unable to read Secret label")
 	diags = utils.DiagsCheckError(diags, data.Set("settings", secret.Settings), "Unable to read Secret settings")
 
+	diags = append(diags, setMetadata(data, "Secret", secret.Path, secret.Metadata)...)
+
 	return diags
 }
 
+// secretPatchFields are the Secret fields sent when their attributes change.
+var secretPatchFields = patchFields{
+	"path":      {"path"},
+	"source_id": {"source_id"},
+	"label":     {"label"},
+	"settings":  {"settings"},
+}
+
 func updateSecret(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 
-	secret := client.Secret{
-		Id:       data.Id(),
-		Path:     data.Get("path").(string),
-		SourceId: data.Get("source_id").(string),
-		Label:    data.Get("label").(string),
-		Settings: getSettings(data),
-	}
-	if _, err := c.Secret.Update(secret); err != nil {
+	secret := buildSecret(data, m)
+	secret.Id = data.Id()
+	patch, err := buildPatch(data, secret, secretPatchFields)
+	if err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to update Secret"))
+		return diags
+	}
+
+	if len(patch) > 0 {
+		if _, err := ifMatch(c, data).Secret.Patch(data.Id(), patch); err != nil {
+			diags = append(diags, utils.DiagFromError(err, "Unable to update Secret"))
+			return diags
+		}
+	}
+
+	if data.HasChange("value_wo_version") {
+		if diags = append(diags, setSecretValue(data, c)...); diags.HasError() {
+			// Keep the prior state, including value_wo_version, so that the value is sent again on the next apply.
+			data.Partial(true)
+		}
 	}
 
 	return diags
@@ -115,10 +190,14 @@ func updateSecret(_ context.Context, data *schema.ResourceData, meta interface{}
 
 func deleteSecret(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	if _, err := c.Secret.Delete(id); err != nil {
+	if diags = checkDeletion(data, c, "Secret", nil); diags.HasError() {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).Secret.Delete(id); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to delete Secret"))
 	}
 
@@ -12,8 +12,8 @@ func TestAccSymSecret_basic(t *testing.T) {
 	updateData := BuildTestData("more-secret")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: awsSecretsManagerSecretConfig(createData, "A Secret", "mySecret"),
@@ -37,6 +37,49 @@ func TestAccSymSecret_basic(t *testing.T) {
 	})
 }
 
+// TestAccSymSecret_valueWO requires Terraform 1.11 or later, which supports write-only attributes.
+func TestAccSymSecret_valueWO(t *testing.T) {
+	data := BuildTestData("secret-value")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: awsSecretsManagerSecretValueConfig(data, "first-value", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("sym_secret.secret", "value_wo"),
+					resource.TestCheckResourceAttr("sym_secret.secret", "value_wo_version", "1"),
+				),
+			},
+			{
+				Config: awsSecretsManagerSecretValueConfig(data, "second-value", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("sym_secret.secret", "value_wo"),
+					resource.TestCheckResourceAttr("sym_secret.secret", "value_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func awsSecretsManagerSecretValueConfig(t TestData, value string, version int) string {
+	var sb strings.Builder
+
+	sb.WriteString(awsSecretsManagerSourceConfig(t, "Secrets Manager"))
+
+	sb.WriteString(secretResource{
+		terraformName:  "secret",
+		label:          "A Secret",
+		path:           t.ResourceName + "/secret/value",
+		sourceId:       "sym_secrets.aws.id",
+		valueWO:        value,
+		valueWOVersion: version,
+	}.String())
+
+	return sb.String()
+}
+
 func awsSecretsManagerSecretConfig(t TestData, label, jsonKey string) string {
 	var sb strings.Builder
 
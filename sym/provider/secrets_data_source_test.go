@@ -12,8 +12,8 @@ func TestAccSymDataSourceSecretSource_awsSecretsManager(t *testing.T) {
 	data := BuildTestData("secrets-manager")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: awsSecretsManagerDataSourceSecretSource(data),
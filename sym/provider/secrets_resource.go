@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/symopsio/terraform-provider-sym/sym/client"
@@ -12,36 +13,74 @@ import (
 )
 
 func Secrets() *schema.Resource {
+	// SecretsSchema is shared with the sym_secrets data source, which has no deletion_protection or full_name.
+	secretsSchema := SecretsSchema()
+	secretsSchema["deletion_protection"] = utils.DeletionProtection()
+	secretsSchema["full_name"] = fullNameSchema()
+
 	return &schema.Resource{
 		Description:   "The `sym_secrets` resource allows you to specify a source for secrets to be accessed by the Sym platform.",
-		Schema:        SecretsSchema(),
+		Schema:        secretsSchema,
 		CreateContext: createSecrets,
 		ReadContext:   readSecrets,
 		UpdateContext: updateSecrets,
 		DeleteContext: deleteSecrets,
+		CustomizeDiff: customdiff.All(
+			customizeDiffFullName,
+			validateOnPlan("Secrets", secretsPatchFields, validateSecretsOnPlan),
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: getNameAndTypeImporter("secrets"),
+			StateContext: getImporter("secrets", secretsImportCandidates),
 		},
 	}
 }
 
 func SecretsSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"type":     utils.Required(schema.TypeString, "The type of Secrets source."),
-		"name":     utils.RequiredCaseInsensitiveString("A unique identifier for this Secrets source."),
-		"label":    utils.Optional(schema.TypeString, "A label for this Secrets source."),
-		"settings": utils.SettingsMap("A map of settings specific to this type of Secrets source."),
+		"type":               utils.Required(schema.TypeString, "The type of Secrets source."),
+		"name":               utils.RequiredCaseInsensitiveString("A unique identifier for this Secrets source."),
+		"label":              utils.Optional(schema.TypeString, "A label for this Secrets source."),
+		"settings":           utils.SettingsMap("A map of settings specific to this type of Secrets source."),
+		"sensitive_settings": utils.SensitiveSettingsMap("this type of Secrets source"),
 	}
 }
 
-func createSecrets(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client.ApiClient)
+// secretsImportCandidates lists every Secrets that may be imported as a sym_secrets.
+var secretsImportCandidates = importCandidatesOf(
+	func(c *client.ApiClient) ([]client.Secrets, error) { return c.Secrets.List(nil) },
+	func(secrets client.Secrets) importCandidate {
+		return importCandidate{Id: secrets.Id, Slug: secrets.Name, Qualifiers: []string{secrets.Type}}
+	},
+)
 
-	secrets := client.Secrets{
+// buildSecrets returns the Secrets configured by the resource.
+func buildSecrets(data resourceGetter, m *providerMeta) (client.Secrets, diag.Diagnostics) {
+	settings, diags := getMergedSettings(data)
+
+	return client.Secrets{
 		Type:     data.Get("type").(string),
-		Name:     data.Get("name").(string),
-		Settings: getSettings(data),
-		Label:    data.Get("label").(string),
+		Name:     m.Naming.fullName(data.Get("name").(string)),
+		Settings: settings,
+		Label:    m.Naming.fullLabel(data.Get("label").(string)),
+	}, diags
+}
+
+func validateSecretsOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	secrets, diags := buildSecrets(d, m)
+	if err := diagsError(diags); err != nil {
+		return err
+	}
+	secrets.Id = d.Id()
+	return m.Client.Secrets.Validate(secrets)
+}
+
+func createSecrets(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	c := m.Client
+
+	secrets, diags := buildSecrets(data, m)
+	if diags.HasError() {
+		return diags
 	}
 
 	id, err := c.Secrets.Create(secrets)
@@ -50,7 +89,7 @@ func createSecrets(_ context.Context, data *schema.ResourceData, meta interface{
 	}
 
 	data.SetId(id)
-	return nil
+	return utils.DiagsCheckError(diags, setHashedSensitiveSettings(data), "Unable to set Secrets sensitive_settings")
 }
 
 func readSecrets(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -59,21 +98,13 @@ func readSecrets(_ context.Context, data *schema.ResourceData, meta interface{})
 		secrets *client.Secrets
 		err     error
 	)
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 	id := data.Id()
 
-	idParts, parseErr := resourceIdToParts(id, "strategy")
-	if parseErr == nil {
-		// If the ID was parsed as `TYPE:SLUG` successfully, perform a lookup using those values.
-		// This means we are in a `terraform import` scenario.
-		secrets, err = c.Secrets.Find(idParts.Slug, idParts.Subtype)
-	} else {
-		// If the ID could not be parsed as `TYPE:SLUG`, we are doing a normal read at apply-time.
-		secrets, err = c.Secrets.Read(id)
-	}
-
+	secrets, err = c.Secrets.Read(id)
 	if err != nil {
-		if isNotFoundError(err) {
+		if utils.IsNotFoundError(err) {
 			log.Println(notFoundWarning("Secrets", id))
 			data.SetId("")
 			return nil
@@ -82,32 +113,47 @@ func readSecrets(_ context.Context, data *schema.ResourceData, meta interface{})
 		return diags
 	}
 
-	// In the case of a normal read, ID will already be set and this is redundant.
-	// In the case of a `terraform import`, we need to set ID since it was previously TYPE:SLUG.
-	// This must happen below the error checking in case the lookup failed.
-	data.SetId(secrets.Id)
-
 	diags = utils.DiagsCheckError(diags, data.Set("type", secrets.Type), "Unable to read Secrets type")
-	diags = utils.DiagsCheckError(diags, data.Set("name", secrets.Name), "Unable to read Secrets name")
-	diags = utils.DiagsCheckError(diags, data.Set("settings", secrets.Settings), "Unable to read Secrets settings")
-	diags = utils.DiagsCheckError(diags, data.Set("label", secrets.Label), "Unable to read Secrets label")
+	diags = utils.DiagsCheckError(diags, data.Set("name", m.Naming.readName(data.Get("name").(string), secrets.Name)), "Unable to read Secrets name")
+	diags = utils.DiagsCheckError(diags, data.Set("full_name", secrets.Name), "Unable to read Secrets full_name")
+	diags = append(diags, setMergedSettings(data, secrets.Settings)...)
+	diags = utils.DiagsCheckError(diags, data.Set("label", m.Naming.readLabel(data.Get("label").(string), secrets.Label)), "Unable to read Secrets label")
+
+	diags = append(diags, setMetadata(data, "Secrets", secrets.Name, secrets.Metadata)...)
 
 	return diags
 }
 
+// secretsPatchFields are the Secrets fields sent when their attributes change.
+var secretsPatchFields = patchFields{
+	"type":     {"type"},
+	"slug":     {"name", "full_name"},
+	"settings": {"settings", "sensitive_settings"},
+	"label":    {"label"},
+}
+
 func updateSecrets(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 
-	secrets := client.Secrets{
-		Id:       data.Id(),
-		Type:     data.Get("type").(string),
-		Name:     data.Get("name").(string),
-		Settings: getSettings(data),
-		Label:    data.Get("label").(string),
+	secrets, diags := buildSecrets(data, m)
+	if diags.HasError() {
+		return diags
+	}
+	secrets.Id = data.Id()
+	patch, err := buildPatch(data, secrets, secretsPatchFields)
+	if err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to update Secrets"))
+		return diags
+	}
+	if len(patch) == 0 {
+		return diags
 	}
-	if _, err := c.Secrets.Update(secrets); err != nil {
+
+	if _, err := ifMatch(c, data).Secrets.Patch(data.Id(), patch); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to update Secrets"))
+	} else {
+		diags = utils.DiagsCheckError(diags, setHashedSensitiveSettings(data), "Unable to set Secrets sensitive_settings")
 	}
 
 	return diags
@@ -115,10 +161,14 @@ func updateSecrets(_ context.Context, data *schema.ResourceData, meta interface{
 
 func deleteSecrets(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	if _, err := c.Secrets.Delete(id); err != nil {
+	if diags = checkDeletion(data, c, "Secrets", secretsDependents); diags.HasError() {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).Secrets.Delete(id); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to delete Secrets"))
 	}
 
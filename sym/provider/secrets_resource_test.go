@@ -12,8 +12,8 @@ func TestAccSymSecretSource_awsSecretsManager(t *testing.T) {
 	updateData := BuildTestData("updated-secrets-manager")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: awsSecretsManagerSourceConfig(createData, "Very Secret"),
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withListsOf returns a resource with the given schema, in which each of the given set attributes is a list,
+// as they were stored before they became sets.
+func withListsOf(s map[string]*schema.Schema, attributes ...string) *schema.Resource {
+	for _, attribute := range attributes {
+		list := *s[attribute]
+		list.Type = schema.TypeList
+		s[attribute] = &list
+	}
+	return &schema.Resource{Schema: s}
+}
+
+// upgradeListsToSets returns a state upgrader for lists of strings which became sets, as their order has no
+// meaning. The state of a list and a set are encoded alike, so only duplicate values need to be removed.
+func upgradeListsToSets(attributes ...string) schema.StateUpgradeFunc {
+	return func(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+		if rawState == nil {
+			return rawState, nil
+		}
+
+		for _, attribute := range attributes {
+			values, ok := rawState[attribute].([]interface{})
+			if !ok {
+				continue
+			}
+
+			seen := map[interface{}]bool{}
+			unique := []interface{}{}
+			for _, value := range values {
+				if !seen[value] {
+					seen[value] = true
+					unique = append(unique, value)
+				}
+			}
+			rawState[attribute] = unique
+		}
+
+		return rawState, nil
+	}
+}
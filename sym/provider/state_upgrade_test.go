@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_upgradeListsToSets(t *testing.T) {
+	state, err := upgradeListsToSets("log_destination_ids")(context.Background(), map[string]interface{}{
+		"id":                  "environment-id",
+		"log_destination_ids": []interface{}{"b", "a", "b"},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"id":                  "environment-id",
+		"log_destination_ids": []interface{}{"b", "a"},
+	}, state)
+}
+
+func Test_withListsOf(t *testing.T) {
+	r := withListsOf(environmentSchema(), "log_destination_ids")
+
+	assert.Equal(t, schema.TypeList, r.Schema["log_destination_ids"].Type)
+	assert.Equal(t, schema.TypeSet, environmentSchema()["log_destination_ids"].Type)
+	assert.NoError(t, r.InternalValidate(nil, true))
+}
+
+// Test_setsIgnoreOrder checks that IDs whose order has no meaning do not cause a diff when they are reordered.
+func Test_setsIgnoreOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		resource  *schema.Resource
+		attribute string
+		config    map[string]interface{}
+	}{
+		{"sym_environment", Environment(), "log_destination_ids", map[string]interface{}{"name": "prod"}},
+		{"sym_target", Target(), "field_bindings", map[string]interface{}{"type": "okta_group", "name": "prod"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := tt.resource.TestResourceData()
+			prior.SetId("id")
+			for k, v := range tt.config {
+				require.NoError(t, prior.Set(k, v))
+			}
+			require.NoError(t, prior.Set("full_name", "prod"))
+			require.NoError(t, prior.Set("deletion_protection", false))
+			require.NoError(t, prior.Set(tt.attribute, []interface{}{"a", "b"}))
+
+			config := map[string]interface{}{tt.attribute: []interface{}{"b", "a"}}
+			for k, v := range tt.config {
+				config[k] = v
+			}
+
+			diff, err := tt.resource.Diff(context.Background(), prior.State(), terraform.NewResourceConfigRaw(config), nil)
+			require.NoError(t, err)
+			assert.True(t, diff.Empty(), "unexpected diff: %v", diff)
+		})
+	}
+}
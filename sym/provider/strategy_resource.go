@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/symopsio/terraform-provider-sym/sym/client"
@@ -18,24 +19,70 @@ func Strategy() *schema.Resource {
 	return &schema.Resource{
 		Description:   "The `sym_strategy` resource allows you specify a set of Targets, and a way of granting access to those Targets.",
 		Schema:        strategySchema(),
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    strategyResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeStrategyStateV0,
+			},
+			{
+				Version: 1,
+				Type:    withListsOf(strategySchema(), "targets", "target").CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeListsToSets("targets"),
+			},
+		},
 		CreateContext: createStrategy,
 		ReadContext:   readStrategy,
 		UpdateContext: updateStrategy,
 		DeleteContext: deleteStrategy,
+		CustomizeDiff: customdiff.All(
+			customizeDiffFullName,
+			customizeDiffStrategyTargets,
+			validateOnPlan("Strategy", strategyPatchFields, validateStrategyOnPlan),
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: getNameAndTypeImporter("strategy"),
+			StateContext: getImporter("strategy", strategyImportCandidates),
 		},
 	}
 }
 
 func strategySchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"type":           utils.Required(schema.TypeString, "The type of the Strategy."),
-		"integration_id": utils.Optional(schema.TypeString, "The ID of the `sym_integration` associated with this Strategy."),
-		"settings":       utils.SettingsMap("A map of settings specific to this type of Strategy."),
-		"targets":        utils.StringList(true, "A list of IDs for targets associated with this Strategy."),
-		"name":           utils.RequiredCaseInsensitiveString("A unique identifier for this Strategy."),
-		"label":          utils.Optional(schema.TypeString, "An optional label for this Strategy."),
+		"type":               utils.Required(schema.TypeString, "The type of the Strategy."),
+		"integration_id":     utils.Optional(schema.TypeString, "The ID of the `sym_integration` associated with this Strategy."),
+		"settings":           utils.SettingsMap("A map of settings specific to this type of Strategy."),
+		"sensitive_settings": utils.SensitiveSettingsMap("this type of Strategy"),
+		"targets": {
+			Type:         schema.TypeSet,
+			Elem:         &schema.Schema{Type: schema.TypeString},
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"targets", "target"},
+			Deprecated:   "Use `target` blocks instead, which may also set tags for each Target.",
+			Description:  "A set of IDs for targets associated with this Strategy.",
+		},
+		"target": {
+			Type:         schema.TypeSet,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"targets", "target"},
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": utils.Required(schema.TypeString, "The ID of the `sym_target`."),
+					"tags": {
+						Type:        schema.TypeMap,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Optional:    true,
+						Description: "A map of tags for this Target, which the Strategy's implementation may use to handle each Target differently.",
+					},
+				},
+			},
+			Description: "A Target associated with this Strategy.",
+		},
+		"name":      utils.RequiredCaseInsensitiveString("A unique identifier for this Strategy."),
+		"full_name": fullNameSchema(),
+		"label":     utils.Optional(schema.TypeString, "An optional label for this Strategy."),
 		"implementation": {
 			Type:             schema.TypeString,
 			Optional:         true,
@@ -45,6 +92,7 @@ func strategySchema() map[string]*schema.Schema {
 			},
 			Description: "Relative path to the implementation written in python if this is a custom strategy.",
 		},
+		"deletion_protection": utils.DeletionProtection(),
 	}
 }
 
@@ -64,20 +112,142 @@ func validateStrategy(diags diag.Diagnostics, strategy *client.Strategy) diag.Di
 	return diags
 }
 
-func createStrategy(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+// strategyImportCandidates lists every Strategy that may be imported as a sym_strategy.
+var strategyImportCandidates = importCandidatesOf(
+	func(c *client.ApiClient) ([]client.Strategy, error) { return c.Strategy.List(nil) },
+	func(strategy client.Strategy) importCandidate {
+		return importCandidate{Id: strategy.Id, Slug: strategy.Name, Qualifiers: []string{strategy.Type}}
+	},
+)
+
+// buildStrategy returns the Strategy configured by the resource, without its implementation.
+func buildStrategy(data resourceGetter, m *providerMeta) (client.Strategy, diag.Diagnostics) {
+	settings, diags := getMergedSettings(data)
 
 	strategy := client.Strategy{
 		Type:          data.Get("type").(string),
-		Settings:      getSettings(data),
+		Settings:      settings,
 		IntegrationId: data.Get("integration_id").(string),
-		Name:          data.Get("name").(string),
-		Label:         data.Get("label").(string),
+		Name:          m.Naming.fullName(data.Get("name").(string)),
+		Label:         m.Naming.fullLabel(data.Get("label").(string)),
+	}
+	// `target` is kept in sync with `targets` by customizeDiffStrategyTargets, so it holds the Targets whichever is configured.
+	for _, target := range strategyTargetBlocks(data.Get("target")) {
+		strategyTarget := client.StrategyTarget{TargetId: target["id"].(string), Tags: client.Tags{}}
+		for k, v := range target["tags"].(map[string]interface{}) {
+			strategyTarget.Tags[k] = v.(string)
+		}
+		strategy.Targets = append(strategy.Targets, strategyTarget)
+	}
+
+	return strategy, validateStrategy(diags, &strategy)
+}
+
+// strategyTargetBlocks returns the `target` blocks in a set of them. Blocks without an ID are skipped, as the SDK
+// reads a removed block whose tags were set back from the diff as an empty block.
+func strategyTargetBlocks(set interface{}) []map[string]interface{} {
+	var blocks []map[string]interface{}
+	for _, t := range set.(*schema.Set).List() {
+		if block := t.(map[string]interface{}); block["id"] != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// customizeDiffStrategyTargets plans whichever of `targets` and `target` is not configured from the one that is,
+// so that either may be used. Targets configured by ID keep the tags they already have.
+func customizeDiffStrategyTargets(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	if rawConfig.GetAttr("targets").IsNull() {
+		if !d.NewValueKnown("target") {
+			return d.SetNewComputed("targets")
+		}
+		if !d.HasChange("target") {
+			return nil
+		}
+
+		var ids []interface{}
+		for _, target := range strategyTargetBlocks(d.Get("target")) {
+			ids = append(ids, target["id"])
+		}
+		return d.SetNew("targets", ids)
+	}
+
+	if !d.NewValueKnown("targets") {
+		return d.SetNewComputed("target")
+	}
+	if !d.HasChange("targets") {
+		return nil
+	}
+
+	oldTargets, _ := d.GetChange("target")
+	tags := map[string]interface{}{}
+	for _, target := range strategyTargetBlocks(oldTargets) {
+		tags[target["id"].(string)] = target["tags"]
+	}
+
+	var targets []interface{}
+	for _, id := range d.Get("targets").(*schema.Set).List() {
+		target := map[string]interface{}{"id": id}
+		if t, ok := tags[id.(string)]; ok {
+			target["tags"] = t
+		}
+		targets = append(targets, target)
+	}
+	return d.SetNew("target", targets)
+}
+
+// strategyResourceV0 is the schema of sym_strategy before `target` blocks were added.
+func strategyResourceV0() *schema.Resource {
+	s := strategySchema()
+	delete(s, "target")
+	s["targets"] = utils.StringList(true, "A list of IDs for targets associated with this Strategy.")
+	return &schema.Resource{Schema: s}
+}
+
+// upgradeStrategyStateV0 adds a `target` block without tags for each Target in `targets`.
+func upgradeStrategyStateV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
 	}
-	targets := data.Get("targets").([]interface{})
-	for i := range targets {
-		strategy.Targets = append(strategy.Targets, targets[i].(string))
+
+	targets, _ := rawState["targets"].([]interface{})
+	target := make([]interface{}, len(targets))
+	for i, id := range targets {
+		target[i] = map[string]interface{}{"id": id, "tags": map[string]interface{}{}}
+	}
+	rawState["target"] = target
+
+	return rawState, nil
+}
+
+func validateStrategyOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	strategy, diags := buildStrategy(d, m)
+	if err := diagsError(diags); err != nil {
+		return err
+	}
+	strategy.Id = d.Id()
+
+	// The implementation file may not exist until apply, e.g. if it is generated, so it is only validated if it can be read.
+	if b, err := os.ReadFile(d.Get("implementation").(string)); err == nil {
+		strategy.Implementation = base64.StdEncoding.EncodeToString(b)
+	}
+
+	return m.Client.Strategy.Validate(strategy)
+}
+
+func createStrategy(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	c := m.Client
+
+	strategy, diags := buildStrategy(data, m)
+	if diags.HasError() {
+		return diags
 	}
 
 	implementation := data.Get("implementation").(string)
@@ -89,8 +259,7 @@ func createStrategy(_ context.Context, data *schema.ResourceData, meta interface
 			strategy.Implementation = base64.StdEncoding.EncodeToString(b)
 		}
 	}
-
-	if diags = validateStrategy(diags, &strategy); diags.HasError() {
+	if diags.HasError() {
 		return diags
 	}
 
@@ -99,6 +268,7 @@ func createStrategy(_ context.Context, data *schema.ResourceData, meta interface
 		diags = utils.DiagsCheckError(diags, err, "Unable to create Strategy")
 	} else {
 		data.SetId(id)
+		diags = utils.DiagsCheckError(diags, setHashedSensitiveSettings(data), "Unable to set Strategy sensitive_settings")
 	}
 	return diags
 }
@@ -109,21 +279,13 @@ func readStrategy(_ context.Context, data *schema.ResourceData, meta interface{}
 		strategy *client.Strategy
 		err      error
 	)
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 	id := data.Id()
 
-	idParts, parseErr := resourceIdToParts(id, "strategy")
-	if parseErr == nil {
-		// If the ID was parsed as `TYPE:SLUG` successfully, perform a lookup using those values.
-		// This means we are in a `terraform import` scenario.
-		strategy, err = c.Strategy.Find(idParts.Slug, idParts.Subtype)
-	} else {
-		// If the ID could not be parsed as `TYPE:SLUG`, we are doing a normal read at apply-time.
-		strategy, err = c.Strategy.Read(id)
-	}
-
+	strategy, err = c.Strategy.Read(id)
 	if err != nil {
-		if isNotFoundError(err) {
+		if utils.IsNotFoundError(err) {
 			log.Println(notFoundWarning("Strategy", id))
 			data.SetId("")
 			return nil
@@ -132,40 +294,56 @@ func readStrategy(_ context.Context, data *schema.ResourceData, meta interface{}
 		return diags
 	}
 
-	// In the case of a normal read, ID will already be set and this is redundant.
-	// In the case of a `terraform import`, we need to set ID since it was previously TYPE:SLUG.
-	// This must happen below the error checking in case the lookup failed.
-	data.SetId(strategy.Id)
-
 	diags = utils.DiagsCheckError(diags, data.Set("type", strategy.Type), "Unable to read Strategy type")
 	diags = utils.DiagsCheckError(diags, data.Set("integration_id", strategy.IntegrationId), "Unable to read Strategy integration_id")
-	diags = utils.DiagsCheckError(diags, data.Set("targets", strategy.Targets), "Unable to read Strategy targets")
-	diags = utils.DiagsCheckError(diags, data.Set("settings", strategy.Settings), "Unable to read Strategy settings")
-	diags = utils.DiagsCheckError(diags, data.Set("name", strategy.Name), "Unable to read Strategy name")
-	diags = utils.DiagsCheckError(diags, data.Set("label", strategy.Label), "Unable to read Strategy label")
+	diags = utils.DiagsCheckError(diags, data.Set("targets", strategy.TargetIds()), "Unable to read Strategy targets")
+	diags = utils.DiagsCheckError(diags, data.Set("target", flattenStrategyTargets(strategy.Targets)), "Unable to read Strategy target")
+	diags = append(diags, setMergedSettings(data, strategy.Settings)...)
+	diags = utils.DiagsCheckError(diags, data.Set("name", m.Naming.readName(data.Get("name").(string), strategy.Name)), "Unable to read Strategy name")
+	diags = utils.DiagsCheckError(diags, data.Set("full_name", strategy.Name), "Unable to read Strategy full_name")
+	diags = utils.DiagsCheckError(diags, data.Set("label", m.Naming.readLabel(data.Get("label").(string), strategy.Label)), "Unable to read Strategy label")
 
 	// Base64 -> Text
 	diags = utils.DiagsCheckError(diags, data.Set("implementation", utils.ParseRemoteImpl(strategy.Implementation)), "Unable to read AccessStrategy implementation")
 
+	diags = append(diags, setMetadata(data, "Strategy", strategy.Name, strategy.Metadata)...)
+
 	return diags
 }
 
+// flattenStrategyTargets returns the `target` blocks of the given Strategy Targets.
+func flattenStrategyTargets(targets []client.StrategyTarget) []interface{} {
+	flattened := make([]interface{}, len(targets))
+	for i, target := range targets {
+		tags := map[string]interface{}{}
+		for k, v := range target.Tags {
+			tags[k] = v
+		}
+		flattened[i] = map[string]interface{}{"id": target.TargetId, "tags": tags}
+	}
+	return flattened
+}
+
+// strategyPatchFields are the Strategy fields sent when their attributes change.
+var strategyPatchFields = patchFields{
+	"type":           {"type"},
+	"integration_id": {"integration_id"},
+	"targets":        {"targets", "target"},
+	"settings":       {"settings", "sensitive_settings"},
+	"slug":           {"name", "full_name"},
+	"label":          {"label"},
+	"implementation": {"implementation"},
+}
+
 func updateStrategy(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 
-	strategy := client.Strategy{
-		Id:            data.Id(),
-		Type:          data.Get("type").(string),
-		IntegrationId: data.Get("integration_id").(string),
-		Settings:      getSettings(data),
-		Name:          data.Get("name").(string),
-		Label:         data.Get("label").(string),
-	}
-	targets := data.Get("targets").([]interface{})
-	for i := range targets {
-		strategy.Targets = append(strategy.Targets, targets[i].(string))
+	strategy, diags := buildStrategy(data, m)
+	if diags.HasError() {
+		return diags
 	}
+	strategy.Id = data.Id()
 
 	implementation := data.Get("implementation").(string)
 
@@ -194,12 +372,19 @@ func updateStrategy(_ context.Context, data *schema.ResourceData, meta interface
 		}
 	}
 
-	if diags = validateStrategy(diags, &strategy); diags.HasError() {
+	patch, err := buildPatch(data, strategy, strategyPatchFields)
+	if err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to update Strategy"))
+		return diags
+	}
+	if len(patch) == 0 {
 		return diags
 	}
 
-	if _, err := c.Strategy.Update(strategy); err != nil {
+	if _, err := ifMatch(c, data).Strategy.Patch(data.Id(), patch); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to update Strategy"))
+	} else {
+		diags = utils.DiagsCheckError(diags, setHashedSensitiveSettings(data), "Unable to set Strategy sensitive_settings")
 	}
 
 	return diags
@@ -207,10 +392,14 @@ func updateStrategy(_ context.Context, data *schema.ResourceData, meta interface
 
 func deleteStrategy(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	if _, err := c.Strategy.Delete(id); err != nil {
+	if diags = checkDeletion(data, c, "Strategy", strategyDependents); diags.HasError() {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).Strategy.Delete(id); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to delete Strategy"))
 	}
 
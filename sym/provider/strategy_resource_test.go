@@ -1,10 +1,18 @@
 package provider
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
 )
 
 const instanceArnPrefix = "arn:aws:::instance/ssoinst-"
@@ -14,11 +22,11 @@ func TestAccSymStrategy_awsSso(t *testing.T) {
 	updateData := BuildTestData("updated-strategy")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: awsSsoStrategy(createData, "SSO Strategy", "foo"),
+				Config: awsSsoStrategy(createData, "SSO Strategy", "foo", "low"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("sym_strategy.sso", "type", "aws_sso"),
 					resource.TestCheckResourceAttr("sym_strategy.sso", "name", createData.ResourceName),
@@ -26,11 +34,14 @@ func TestAccSymStrategy_awsSso(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_strategy.sso", "settings.instance_arn", instanceArnPrefix+"foo"),
 					resource.TestCheckResourceAttrPair("sym_strategy.sso", "integration_id", "sym_integration.sso", "id"),
 					resource.TestCheckResourceAttr("sym_strategy.sso", "targets.#", "1"),
-					resource.TestCheckResourceAttrPair("sym_strategy.sso", "targets.0", "sym_target.sso", "id"),
+					resource.TestCheckTypeSetElemAttrPair("sym_strategy.sso", "targets.*", "sym_target.sso", "id"),
+					resource.TestCheckResourceAttr("sym_strategy.sso", "target.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("sym_strategy.sso", "target.*.id", "sym_target.sso", "id"),
+					resource.TestCheckTypeSetElemNestedAttrs("sym_strategy.sso", "target.*", map[string]string{"tags.%": "1", "tags.risk": "low"}),
 				),
 			},
 			{
-				Config: awsSsoStrategy(updateData, "Updated SSO Strategy", "bar"),
+				Config: awsSsoStrategy(updateData, "Updated SSO Strategy", "bar", "high"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("sym_strategy.sso", "type", "aws_sso"),
 					resource.TestCheckResourceAttr("sym_strategy.sso", "name", updateData.ResourceName),
@@ -38,7 +49,10 @@ func TestAccSymStrategy_awsSso(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_strategy.sso", "settings.instance_arn", instanceArnPrefix+"bar"),
 					resource.TestCheckResourceAttrPair("sym_strategy.sso", "integration_id", "sym_integration.sso", "id"),
 					resource.TestCheckResourceAttr("sym_strategy.sso", "targets.#", "1"),
-					resource.TestCheckResourceAttrPair("sym_strategy.sso", "targets.0", "sym_target.sso", "id"),
+					resource.TestCheckTypeSetElemAttrPair("sym_strategy.sso", "targets.*", "sym_target.sso", "id"),
+					resource.TestCheckResourceAttr("sym_strategy.sso", "target.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("sym_strategy.sso", "target.*.id", "sym_target.sso", "id"),
+					resource.TestCheckTypeSetElemNestedAttrs("sym_strategy.sso", "target.*", map[string]string{"tags.%": "1", "tags.risk": "high"}),
 				),
 			},
 		},
@@ -50,8 +64,8 @@ func TestAccSymStrategy_custom(t *testing.T) {
 	updateData := BuildTestData("updated-strategy")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: customStrategy(createData, "Custom Strategy", "internal/testdata/before_strategy_impl.py"),
@@ -61,7 +75,9 @@ func TestAccSymStrategy_custom(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_strategy.custom", "label", "Custom Strategy"),
 					resource.TestCheckResourceAttrPair("sym_strategy.custom", "integration_id", "sym_integration.custom", "id"),
 					resource.TestCheckResourceAttr("sym_strategy.custom", "targets.#", "1"),
-					resource.TestCheckResourceAttrPair("sym_strategy.custom", "targets.0", "sym_target.custom", "id"),
+					resource.TestCheckTypeSetElemAttrPair("sym_strategy.custom", "targets.*", "sym_target.custom", "id"),
+					resource.TestCheckResourceAttr("sym_strategy.custom", "target.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("sym_strategy.custom", "target.*.id", "sym_target.custom", "id"),
 				),
 			},
 			{
@@ -72,14 +88,102 @@ func TestAccSymStrategy_custom(t *testing.T) {
 					resource.TestCheckResourceAttr("sym_strategy.custom", "label", "Updated Custom Strategy"),
 					resource.TestCheckResourceAttrPair("sym_strategy.custom", "integration_id", "sym_integration.custom", "id"),
 					resource.TestCheckResourceAttr("sym_strategy.custom", "targets.#", "1"),
-					resource.TestCheckResourceAttrPair("sym_strategy.custom", "targets.0", "sym_target.custom", "id"),
+					resource.TestCheckTypeSetElemAttrPair("sym_strategy.custom", "targets.*", "sym_target.custom", "id"),
+					resource.TestCheckResourceAttr("sym_strategy.custom", "target.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("sym_strategy.custom", "target.*.id", "sym_target.custom", "id"),
 				),
 			},
 		},
 	})
 }
 
-func awsSsoStrategy(t TestData, label string, arnSuffix string) string {
+func Test_upgradeStrategyStateV0(t *testing.T) {
+	state, err := upgradeStrategyStateV0(context.Background(), map[string]interface{}{
+		"id":      "strategy-id",
+		"targets": []interface{}{"target-a", "target-b"},
+	}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"id":      "strategy-id",
+		"targets": []interface{}{"target-a", "target-b"},
+		"target": []interface{}{
+			map[string]interface{}{"id": "target-a", "tags": map[string]interface{}{}},
+			map[string]interface{}{"id": "target-b", "tags": map[string]interface{}{}},
+		},
+	}, state)
+}
+
+func Test_customizeDiffStrategyTargets(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		config      map[string]interface{}
+		wantTargets []interface{}
+		wantTarget  []map[string]interface{}
+	}{
+		{
+			"targets",
+			map[string]interface{}{"targets": []interface{}{"target-a", "target-b"}},
+			[]interface{}{"target-a", "target-b"},
+			[]map[string]interface{}{
+				{"id": "target-a", "tags": map[string]interface{}{"risk": "high"}},
+				{"id": "target-b", "tags": map[string]interface{}{}},
+			},
+		},
+		{
+			"target",
+			map[string]interface{}{"target": []interface{}{
+				map[string]interface{}{"id": "target-b", "tags": map[string]interface{}{"risk": "low"}},
+			}},
+			[]interface{}{"target-b"},
+			[]map[string]interface{}{
+				{"id": "target-b", "tags": map[string]interface{}{"risk": "low"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]interface{}{"type": "aws_sso", "name": "sso"}
+			rawTargets := cty.NullVal(cty.List(cty.String))
+			for k, v := range tt.config {
+				config[k] = v
+				if k == "targets" {
+					rawTargets = cty.ListVal([]cty.Value{cty.StringVal("target-a"), cty.StringVal("target-b")})
+				}
+			}
+
+			var targets []interface{}
+			var target []map[string]interface{}
+			r := &schema.Resource{
+				Schema: strategySchema(),
+				CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+					err := customizeDiffStrategyTargets(ctx, d, meta)
+					targets = d.Get("targets").(*schema.Set).List()
+					target = strategyTargetBlocks(d.Get("target"))
+					return err
+				},
+			}
+			prior := r.TestResourceData()
+			prior.SetId("strategy-id")
+			require.NoError(t, prior.Set("type", "aws_sso"))
+			require.NoError(t, prior.Set("name", "sso"))
+			require.NoError(t, prior.Set("targets", []interface{}{"target-a"}))
+			require.NoError(t, prior.Set("target", flattenStrategyTargets([]client.StrategyTarget{{TargetId: "target-a", Tags: client.Tags{"risk": "high"}}})))
+			instanceState := prior.State()
+			instanceState.RawConfig = cty.ObjectVal(map[string]cty.Value{"targets": rawTargets})
+
+			_, err := r.Diff(context.Background(), instanceState, terraform.NewResourceConfigRaw(config), nil)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, tt.wantTargets, targets)
+			assert.ElementsMatch(t, tt.wantTarget, target)
+		})
+	}
+}
+
+func awsSsoStrategy(t TestData, label, arnSuffix, risk string) string {
 	var sb strings.Builder
 
 	sb.WriteString(providerResource{org: t.OrgSlug}.String())
@@ -115,7 +219,7 @@ func awsSsoStrategy(t TestData, label string, arnSuffix string) string {
 		type_:          "aws_sso",
 		label:          label,
 		integrationId:  "sym_integration.sso.id",
-		targetIds:      []string{"sym_target.sso.id"},
+		targets:        []strategyTarget{{id: "sym_target.sso.id", tags: map[string]string{"risk": risk}}},
 		implementation: "",
 		settings: map[string]string{
 			"instance_arn": instanceArnPrefix + arnSuffix,
@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/symopsio/terraform-provider-sym/sym/client"
@@ -15,39 +16,72 @@ func Target() *schema.Resource {
 	return &schema.Resource{
 		Description:   "The `sym_target` resource allows you to describe something that users can request access to.",
 		Schema:        targetSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    withListsOf(targetSchema(), "field_bindings").CoreConfigSchema().ImpliedType(),
+				Upgrade: upgradeListsToSets("field_bindings"),
+			},
+		},
 		CreateContext: createTarget,
 		ReadContext:   readTarget,
 		UpdateContext: updateTarget,
 		DeleteContext: deleteTarget,
+		CustomizeDiff: customdiff.All(
+			customizeDiffFullName,
+			validateOnPlan("Target", targetPatchFields, validateTargetOnPlan),
+		),
 		Importer: &schema.ResourceImporter{
-			StateContext: getNameAndTypeImporter("target"),
+			StateContext: getImporter("target", targetImportCandidates),
 		},
 	}
 }
 
 func targetSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"type":           utils.Required(schema.TypeString, "The type of the Target."),
-		"name":           utils.RequiredCaseInsensitiveString("A unique identifier for the Target."),
-		"label":          utils.Optional(schema.TypeString, "An optional label for this Target."),
-		"field_bindings": utils.StringList(false, "Settings whose values will be dynamically populated by submitted request values. See [docs](https://docs.symops.com/docs/dynamic-target-settings) for more details."),
-		"settings":       utils.SettingsMap("Map of settings specific to this type of Target."),
+		"type":                utils.Required(schema.TypeString, "The type of the Target."),
+		"name":                utils.RequiredCaseInsensitiveString("A unique identifier for the Target."),
+		"full_name":           fullNameSchema(),
+		"label":               utils.Optional(schema.TypeString, "An optional label for this Target."),
+		"field_bindings":      utils.StringSet(false, "Settings whose values will be dynamically populated by submitted request values. See [docs](https://docs.symops.com/docs/dynamic-target-settings) for more details."),
+		"settings":            utils.SettingsMap("Map of settings specific to this type of Target."),
+		"deletion_protection": utils.DeletionProtection(),
 	}
 }
 
-func createTarget(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client.ApiClient)
+// targetImportCandidates lists every Target that may be imported as a sym_target.
+var targetImportCandidates = importCandidatesOf(
+	func(c *client.ApiClient) ([]client.Target, error) { return c.Target.List(nil) },
+	func(target client.Target) importCandidate {
+		return importCandidate{Id: target.Id, Slug: target.Name, Qualifiers: []string{target.Type}}
+	},
+)
+
+// buildTarget returns the Target configured by the resource.
+func buildTarget(data resourceGetter, m *providerMeta) client.Target {
 	target := client.Target{
 		Type:     data.Get("type").(string),
-		Name:     data.Get("name").(string),
-		Label:    data.Get("label").(string),
+		Name:     m.Naming.fullName(data.Get("name").(string)),
+		Label:    m.Naming.fullLabel(data.Get("label").(string)),
 		Settings: getSettings(data),
 	}
 
-	fieldBindings := data.Get("field_bindings").([]interface{})
-	for i := range fieldBindings {
-		target.FieldBindings = append(target.FieldBindings, fieldBindings[i].(string))
-	}
+	target.FieldBindings = utils.SetStrings(data.Get("field_bindings").(*schema.Set))
+
+	return target
+}
+
+func validateTargetOnPlan(d *schema.ResourceDiff, m *providerMeta) error {
+	target := buildTarget(d, m)
+	target.Id = d.Id()
+	return m.Client.Target.Validate(target)
+}
+
+func createTarget(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*providerMeta)
+	c := m.Client
+	target := buildTarget(data, m)
 
 	id, err := c.Target.Create(target)
 	if err != nil {
@@ -64,21 +98,13 @@ func readTarget(_ context.Context, data *schema.ResourceData, meta interface{})
 		target *client.Target
 		err    error
 	)
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 	id := data.Id()
 
-	idParts, parseErr := resourceIdToParts(id, "target")
-	if parseErr == nil {
-		// If the ID was parsed as `TYPE:SLUG` successfully, perform a lookup using those values.
-		// This means we are in a `terraform import` scenario.
-		target, err = c.Target.Find(idParts.Slug, idParts.Subtype)
-	} else {
-		// If the ID could not be parsed as `TYPE:SLUG`, we are doing a normal read at apply-time.
-		target, err = c.Target.Read(id)
-	}
-
+	target, err = c.Target.Read(id)
 	if err != nil {
-		if isNotFoundError(err) {
+		if utils.IsNotFoundError(err) {
 			log.Println(notFoundWarning("Target", id))
 			data.SetId("")
 			return nil
@@ -87,37 +113,45 @@ func readTarget(_ context.Context, data *schema.ResourceData, meta interface{})
 		return diags
 	}
 
-	// In the case of a normal read, ID will already be set and this is redundant.
-	// In the case of a `terraform import`, we need to set ID since it was previously TYPE:SLUG.
-	// This must happen below the error checking in case the lookup failed.
-	data.SetId(target.Id)
-
 	diags = utils.DiagsCheckError(diags, data.Set("type", target.Type), "Unable to read Target type")
-	diags = utils.DiagsCheckError(diags, data.Set("name", target.Name), "Unable to read Target name")
-	diags = utils.DiagsCheckError(diags, data.Set("label", target.Label), "Unable to read Target label")
+	diags = utils.DiagsCheckError(diags, data.Set("name", m.Naming.readName(data.Get("name").(string), target.Name)), "Unable to read Target name")
+	diags = utils.DiagsCheckError(diags, data.Set("full_name", target.Name), "Unable to read Target full_name")
+	diags = utils.DiagsCheckError(diags, data.Set("label", m.Naming.readLabel(data.Get("label").(string), target.Label)), "Unable to read Target label")
 	diags = utils.DiagsCheckError(diags, data.Set("field_bindings", target.FieldBindings), "Unable to read Target field_bindings")
 	diags = utils.DiagsCheckError(diags, data.Set("settings", target.Settings), "Unable to read Target settings")
 
+	diags = append(diags, setMetadata(data, "Target", target.Name, target.Metadata)...)
+
 	return diags
 }
 
+// targetPatchFields are the Target fields sent when their attributes change.
+var targetPatchFields = patchFields{
+	"slug":           {"name", "full_name"},
+	"type":           {"type"},
+	"label":          {"label"},
+	"field_bindings": {"field_bindings"},
+	"settings":       {"settings"},
+}
+
 func updateTarget(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	m := meta.(*providerMeta)
+	c := m.Client
 
-	target := client.Target{
-		Id:       data.Id(),
-		Type:     data.Get("type").(string),
-		Name:     data.Get("name").(string),
-		Label:    data.Get("label").(string),
-		Settings: getSettings(data),
+	target := buildTarget(data, m)
+	target.Id = data.Id()
+
+	patch, err := buildPatch(data, target, targetPatchFields)
+	if err != nil {
+		diags = append(diags, utils.DiagFromError(err, "Unable to update Target"))
+		return diags
 	}
-	field_bindings := data.Get("field_bindings").([]interface{})
-	for i := range field_bindings {
-		target.FieldBindings = append(target.FieldBindings, field_bindings[i].(string))
+	if len(patch) == 0 {
+		return diags
 	}
 
-	if _, err := c.Target.Update(target); err != nil {
+	if _, err := ifMatch(c, data).Target.Patch(data.Id(), patch); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to update Target"))
 	}
 
@@ -126,10 +160,14 @@ func updateTarget(_ context.Context, data *schema.ResourceData, meta interface{}
 
 func deleteTarget(_ context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	c := meta.(*client.ApiClient)
+	c := meta.(*providerMeta).Client
 	id := data.Id()
 
-	if _, err := c.Target.Delete(id); err != nil {
+	if diags = checkDeletion(data, c, "Target", targetDependents); diags.HasError() {
+		return diags
+	}
+
+	if _, err := ifMatch(c, data).Target.Delete(id); err != nil {
 		diags = append(diags, utils.DiagFromError(err, "Unable to delete Target"))
 	}
 
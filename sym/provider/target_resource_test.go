@@ -14,8 +14,8 @@ func TestAccSymTarget_awsSso(t *testing.T) {
 	updateData := BuildTestData("updated-target")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: awsSsoTarget(createData, "My Target", "foo", "012345678910"),
@@ -46,8 +46,8 @@ func TestAccSymTarget_awsIam(t *testing.T) {
 	updateData := BuildTestData("updated-target")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: awsIamTarget(createData, "My Target", "test-iam-group"),
@@ -76,8 +76,8 @@ func TestAccSymTarget_custom(t *testing.T) {
 	updateData := BuildTestData("custom-updated-target")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: testAccProviderFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProtoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: customTarget(createData, "My Custom Target", "am-target"),
@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure      = &symTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose          = &symTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &symTokenEphemeralResource{}
+)
+
+// tokenIdPrivateKey is the private data key storing the tokenPrivateData of a token to revoke when it is closed.
+const tokenIdPrivateKey = "token_id"
+
+// symTokenEphemeralResource issues short-lived tokens for Sym bots. The token is never stored in the Terraform plan
+// or state, so it may only be passed to provider configurations, write-only attributes, and other ephemeral values.
+type symTokenEphemeralResource struct {
+	frameworkResource
+}
+
+func newTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &symTokenEphemeralResource{}
+}
+
+// Configure shadows frameworkResource.Configure, which takes a resource.ConfigureRequest.
+func (r *symTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	r.meta, resp.Diagnostics = providerMetaFrom(req.ProviderData)
+}
+
+func (r *symTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token"
+}
+
+func (r *symTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "The `sym_token` ephemeral resource issues a short-lived Sym API token for a `sym_bot`. " +
+			"The token is never stored in the Terraform plan or state, and requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"org": schema.StringAttribute{
+				Optional:    true,
+				Description: orgDescription,
+			},
+			"bot_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the `sym_bot` to issue the token for.",
+			},
+			"expiry": schema.StringAttribute{
+				Required:    true,
+				Description: "How long the token is valid for, as a number followed by a unit of `s`, `m`, `h`, or `d`, e.g. `30m` or `7d`.",
+			},
+			"label": schema.StringAttribute{
+				Optional:    true,
+				Description: "An optional label describing what the token is used for.",
+			},
+			"scopes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The scopes the token is limited to. If unset, the token has every permission of the bot.",
+			},
+			"revoke_on_close": schema.BoolAttribute{
+				Optional: true,
+				Description: "Whether to revoke the token at the end of the Terraform run. Defaults to `true`, because the " +
+					"token is issued again for every plan and apply. Set it to `false` for a token that must remain valid " +
+					"until it expires, e.g. for a job it was handed to.",
+			},
+			"token_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the token, which may be used to revoke it.",
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The issued token.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the token expires, as an RFC 3339 timestamp.",
+			},
+		},
+	}
+}
+
+// tokenModel is the configuration and result of a sym_token.
+type tokenModel struct {
+	Org           types.String `tfsdk:"org"`
+	BotId         types.String `tfsdk:"bot_id"`
+	Expiry        types.String `tfsdk:"expiry"`
+	Label         types.String `tfsdk:"label"`
+	Scopes        types.List   `tfsdk:"scopes"`
+	RevokeOnClose types.Bool   `tfsdk:"revoke_on_close"`
+	TokenId       types.String `tfsdk:"token_id"`
+	Token         types.String `tfsdk:"token"`
+	ExpiresAt     types.String `tfsdk:"expires_at"`
+}
+
+// parseTokenExpiry parses a token expiry like "30m" or "7d". Unlike time.ParseDuration, days are supported,
+// and each expiry has exactly one unit.
+func parseTokenExpiry(expiry string) (time.Duration, error) {
+	units := map[string]time.Duration{
+		"s": time.Second,
+		"m": time.Minute,
+		"h": time.Hour,
+		"d": 24 * time.Hour,
+	}
+
+	if len(expiry) < 2 {
+		return 0, fmt.Errorf("%q must be a number followed by a unit of s, m, h, or d", expiry)
+	}
+	unit, ok := units[expiry[len(expiry)-1:]]
+	n, err := strconv.ParseInt(strings.TrimSpace(expiry[:len(expiry)-1]), 10, 64)
+	if !ok || err != nil {
+		return 0, fmt.Errorf("%q must be a number followed by a unit of s, m, h, or d", expiry)
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("%q must be positive", expiry)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+func (r *symTokenEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var expiry types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expiry"), &expiry)...)
+	if expiry.IsNull() || expiry.IsUnknown() {
+		return
+	}
+
+	if _, err := parseTokenExpiry(expiry.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expiry"), "Invalid expiry", err.Error())
+	}
+}
+
+func (r *symTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data tokenModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, end := traceOperation(ctx, "sym_token", "open", data.Label)
+	defer func() { end(data.TokenId, resp.Diagnostics) }()
+
+	m, diags := r.metaFor(ctx, data.Org)
+	resp.Diagnostics.Append(diags...)
+	expiry, err := parseTokenExpiry(data.Expiry.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expiry"), "Invalid expiry", err.Error())
+	}
+	var scopes []string
+	if !data.Scopes.IsNull() {
+		resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &scopes, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := m.Client.Token.Issue(client.TokenRequest{
+		BotId:  data.BotId.ValueString(),
+		Expiry: int64(expiry / time.Second),
+		Label:  data.Label.ValueString(),
+		Scopes: scopes,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to issue Token", err.Error())
+		return
+	}
+
+	data.TokenId = types.StringValue(token.Id)
+	data.Token = types.StringValue(token.AccessToken)
+	data.ExpiresAt = types.StringValue(token.ExpiresAt)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+
+	// Tokens are revoked unless revoke_on_close is false, so that a token is not left behind by every plan.
+	if data.RevokeOnClose.IsNull() || data.RevokeOnClose.ValueBool() {
+		private, err := json.Marshal(tokenPrivateData{Id: token.Id, Org: data.Org.ValueString()})
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to store Token ID", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, tokenIdPrivateKey, private)...)
+	}
+}
+
+// tokenPrivateData is the private data of a sym_token that is revoked when it is closed.
+type tokenPrivateData struct {
+	Id  string `json:"id"`
+	Org string `json:"org"`
+}
+
+func (r *symTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	value, diags := req.Private.GetKey(ctx, tokenIdPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || value == nil {
+		// The token is not revoked if `revoke_on_close` was false.
+		return
+	}
+
+	var private tokenPrivateData
+	if err := json.Unmarshal(value, &private); err != nil {
+		resp.Diagnostics.AddError("Unable to read Token ID", err.Error())
+		return
+	}
+
+	ctx, end := traceOperation(ctx, "sym_token", "close", types.StringNull())
+	defer func() { end(types.StringValue(private.Id), resp.Diagnostics) }()
+
+	m, diags := r.metaFor(ctx, types.StringValue(private.Org))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := m.Client.Token.Revoke(private.Id); err != nil && !utils.IsNotFoundError(err) {
+		resp.Diagnostics.AddError("Unable to revoke Token", err.Error())
+	}
+}
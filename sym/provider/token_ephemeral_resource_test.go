@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseTokenExpiry(t *testing.T) {
+	tests := []struct {
+		expiry  string
+		want    time.Duration
+		wantErr string
+	}{
+		{"90s", 90 * time.Second, ""},
+		{"30m", 30 * time.Minute, ""},
+		{"12h", 12 * time.Hour, ""},
+		{"7d", 7 * 24 * time.Hour, ""},
+		{"1h30m", 0, "must be a number followed by a unit"},
+		{"30", 0, "must be a number followed by a unit"},
+		{"d", 0, "must be a number followed by a unit"},
+		{"2w", 0, "must be a number followed by a unit"},
+		{"0h", 0, "must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expiry, func(t *testing.T) {
+			got, err := parseTokenExpiry(tt.expiry)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
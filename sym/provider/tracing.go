@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/symopsio/terraform-provider-sym/sym/tracing"
+)
+
+type crudFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+
+// tracingSchema defines the optional `tracing` block of the provider configuration.
+func tracingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Export OpenTelemetry traces of the provider's resource operations and Sym API calls.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"otlp_endpoint": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The URL of an OTLP/HTTP collector to export spans to, e.g. `http://localhost:4318`.",
+				},
+				"file_path": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The path of a local file to append spans to as JSON.",
+				},
+			},
+		},
+	}
+}
+
+// getTracingConfig reads the `tracing` block of the provider configuration.
+func getTracingConfig(d *schema.ResourceData) tracing.Config {
+	blocks := d.Get("tracing").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return tracing.Config{}
+	}
+
+	block := blocks[0].(map[string]interface{})
+	return tracing.Config{
+		OTLPEndpoint: block["otlp_endpoint"].(string),
+		FilePath:     block["file_path"].(string),
+	}
+}
+
+// traceResources wraps the CRUD functions of every resource in the map with a span. See traceResource.
+func traceResources(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for name, r := range resources {
+		traceResource(name, r)
+	}
+	return resources
+}
+
+// traceResource wraps each of the resource's CRUD functions so that they are run inside a span
+// carrying the resource type, ID, and slug. The client passed to the wrapped function makes its
+// requests with the span's context, so each Sym API call is recorded as a child span.
+func traceResource(resourceType string, r *schema.Resource) {
+	r.CreateContext = traceCrudFunc(resourceType, "create", r.CreateContext)
+	r.ReadContext = traceCrudFunc(resourceType, "read", r.ReadContext)
+	r.UpdateContext = traceCrudFunc(resourceType, "update", r.UpdateContext)
+	r.DeleteContext = traceCrudFunc(resourceType, "delete", r.DeleteContext)
+}
+
+func traceCrudFunc(resourceType, operation string, f crudFunc) crudFunc {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		slug, _ := data.Get("name").(string)
+		ctx, span := startResourceSpan(ctx, resourceType, operation, slug)
+		defer span.End()
+
+		if m, ok := meta.(*providerMeta); ok {
+			meta = m.withContext(ctx)
+		}
+
+		diags := f(ctx, data, meta)
+
+		// The ID is only known after a create, and is cleared when a resource is deleted or not found.
+		if id := data.Id(); id != "" {
+			span.SetAttributes(tracing.ResourceIDKey.String(id))
+		}
+		for _, d := range diags {
+			if d.Severity == diag.Error {
+				recordSpanError(span, d.Summary, d.Detail)
+			}
+		}
+
+		return diags
+	}
+}
+
+// startResourceSpan starts the span of an operation on a resource with the given slug, which may be empty.
+func startResourceSpan(ctx context.Context, resourceType, operation, slug string) (context.Context, trace.Span) {
+	ctx, span := tracing.Tracer().Start(ctx, resourceType+"."+operation)
+
+	span.SetAttributes(tracing.ResourceTypeKey.String(resourceType))
+	if slug != "" {
+		span.SetAttributes(tracing.SlugKey.String(slug))
+	}
+	return ctx, span
+}
+
+// recordSpanError marks the span as failed with the summary and detail of an error diagnostic.
+func recordSpanError(span trace.Span, summary, detail string) {
+	span.SetStatus(codes.Error, summary)
+	span.AddEvent("error", trace.WithAttributes(
+		attribute.String("summary", summary),
+		attribute.String("detail", detail),
+	))
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+	"github.com/symopsio/terraform-provider-sym/sym/tracing"
+)
+
+func Test_getTracingConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  map[string]interface{}
+		want tracing.Config
+	}{
+		{
+			"no-tracing-block",
+			map[string]interface{}{"org": "sym"},
+			tracing.Config{},
+		},
+		{
+			"otlp-endpoint-only",
+			map[string]interface{}{
+				"org":     "sym",
+				"tracing": []interface{}{map[string]interface{}{"otlp_endpoint": "http://localhost:4318"}},
+			},
+			tracing.Config{OTLPEndpoint: "http://localhost:4318"},
+		},
+		{
+			"both-exporters",
+			map[string]interface{}{
+				"org": "sym",
+				"tracing": []interface{}{map[string]interface{}{
+					"otlp_endpoint": "http://localhost:4318",
+					"file_path":     "traces.json",
+				}},
+			},
+			tracing.Config{OTLPEndpoint: "http://localhost:4318", FilePath: "traces.json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := schema.TestResourceDataRaw(t, Provider().Schema, tt.raw)
+			if got := getTracingConfig(data); got != tt.want {
+				t.Errorf("getTracingConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_traceCrudFunc(t *testing.T) {
+	if got := traceCrudFunc("sym_flow", "update", nil); got != nil {
+		t.Errorf("traceCrudFunc() wrapped a nil function")
+	}
+
+	var gotMeta interface{}
+	f := traceCrudFunc("sym_flow", "read", func(_ context.Context, _ *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		gotMeta = meta
+		return diag.Errorf("failed")
+	})
+
+	m := &providerMeta{Client: client.New("token")}
+	data := schema.TestResourceDataRaw(t, Target().Schema, map[string]interface{}{"name": "access"})
+	diags := f(context.Background(), data, m)
+
+	if !diags.HasError() {
+		t.Errorf("traceCrudFunc() did not return the wrapped function's diagnostics")
+	}
+	if got, ok := gotMeta.(*providerMeta); !ok || got == m || got.Client == m.Client {
+		t.Errorf("traceCrudFunc() should pass a context-bound copy of the providerMeta, got %v", gotMeta)
+	}
+}
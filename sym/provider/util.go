@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/symopsio/terraform-provider-sym/sym/client"
@@ -14,11 +16,18 @@ import (
 
 var NullPlaceholder = "<null>"
 
-func getSettings(data *schema.ResourceData) client.Settings {
+// resourceGetter is implemented by both *schema.ResourceData and *schema.ResourceDiff, so that entities can be
+// built from the resource's configuration during apply, or from its plan (see validateOnPlan).
+type resourceGetter interface {
+	Get(key string) interface{}
+	GetRawConfig() cty.Value
+}
+
+func getSettings(data resourceGetter) client.Settings {
 	return getSettingsMap(data, "settings")
 }
 
-func getSettingsMap(data *schema.ResourceData, key string) client.Settings {
+func getSettingsMap(data resourceGetter, key string) client.Settings {
 	rawSettings := data.Get(key).(map[string]interface{})
 	settings := make(map[string]string)
 	for k, v := range rawSettings {
@@ -27,69 +36,243 @@ func getSettingsMap(data *schema.ResourceData, key string) client.Settings {
 	return settings
 }
 
-func isNotFoundError(err error) bool {
-	return strings.Contains(err.Error(), "\nStatus Code: 404\n")
+// getSensitiveSettings returns the plaintext values of the `sensitive_settings` map.
+//
+// The values in the Terraform state are hashes (see utils.SensitiveSettingsMap), and a suppressed
+// diff leaves the hashed value in place, so the plaintext values must be read from the raw config.
+func getSensitiveSettings(data resourceGetter) client.Settings {
+	settings := make(map[string]string)
+
+	rawSettings := data.GetRawConfig().GetAttr("sensitive_settings")
+	if rawSettings.IsNull() || !rawSettings.IsKnown() {
+		return settings
+	}
+
+	for k, v := range rawSettings.AsValueMap() {
+		if v.IsKnown() && !v.IsNull() {
+			settings[k] = v.AsString()
+		}
+	}
+	return settings
 }
 
-func notFoundWarning(resource, id string) string {
-	return fmt.Sprintf("[WARN] Sym %s (%s) not found, removing from state", resource, id)
+// getMergedSettings returns the `settings` and `sensitive_settings` maps merged into the single
+// settings map expected by the Sym API.
+func getMergedSettings(data resourceGetter) (client.Settings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	settings := getSettings(data)
+	for k, v := range getSensitiveSettings(data) {
+		if _, found := settings[k]; found {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Duplicate settings key",
+				Detail:   fmt.Sprintf("The key %q is present in both `settings` and `sensitive_settings`. Please specify it in only one of them.", k),
+			})
+			continue
+		}
+		settings[k] = v
+	}
+
+	return settings, diags
 }
 
-// getSlugImporter returns a function that may be used as a Terraform ResourceImporter. It should be used for any resource
-// which has a ReadContext method that supports fetching from the API with just a slug. For example, see "sym_flow" or
-// "sym_error_logger".
-//
-// This Importer function sets nothing on the resource, and is instead a validator that checks whether the provided
-// ID is not a UUID. The ReadContext methods are responsible for re-parsing the ID to know whether they are in the
-// context of an import.
-func getSlugImporter(resource string) func(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
-	return func(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
-		if _, err := uuid.ParseUUID(data.Id()); err == nil {
-			// If the UUID was parsed successfully, let the user know UUIDs are not allowed.
-			return nil, utils.ErrInvalidImportUUID(resource)
+// setHashedSensitiveSettings stores the hashes of the configured `sensitive_settings` values
+// in the Terraform state, so the plaintext values are never persisted.
+func setHashedSensitiveSettings(data *schema.ResourceData) error {
+	return data.Set("sensitive_settings", utils.HashSettings(getSensitiveSettings(data)))
+}
+
+// setMergedSettings splits the settings map returned by the Sym API back into `settings`
+// and `sensitive_settings`. Any key already tracked in `sensitive_settings` is hashed
+// so that drift is still detected without storing the plaintext value.
+func setMergedSettings(data *schema.ResourceData, apiSettings client.Settings) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	sensitiveKeys := data.Get("sensitive_settings").(map[string]interface{})
+	settings := make(map[string]string)
+	sensitiveSettings := make(map[string]string)
+
+	for k, v := range apiSettings {
+		if _, found := sensitiveKeys[k]; found {
+			sensitiveSettings[k] = utils.HashSensitiveValue(v)
+		} else {
+			settings[k] = v
 		}
+	}
+
+	diags = utils.DiagsCheckError(diags, data.Set("settings", settings), "Unable to read settings")
+	diags = utils.DiagsCheckError(diags, data.Set("sensitive_settings", sensitiveSettings), "Unable to read sensitive_settings")
+
+	return diags
+}
+
+// getImplementation returns the plaintext value of the given implementation attribute.
+//
+// If the provider's `hash_implementations` setting is enabled, the value in the Terraform state is a hash,
+// and a suppressed diff leaves the hashed value in place, so the plaintext value is read from the raw config.
+func getImplementation(data resourceGetter, key string) string {
+	implementation := data.Get(key).(string)
+	if !utils.IsHashedValue(implementation) {
+		return implementation
+	}
 
-		return []*schema.ResourceData{data}, nil
+	rawImplementation := data.GetRawConfig().GetAttr(key)
+	if rawImplementation.IsNull() || !rawImplementation.IsKnown() {
+		return implementation
 	}
+	return rawImplementation.AsString()
 }
 
-type resourceIdParts struct {
-	Subtype string // The ``type`` field for the resource (e.g. "slack" for integration)
-	Slug    string // The ``name`` field for the resource
+// implementationState returns the value an implementation should be stored as in the Terraform state:
+// a hash if the provider's `hash_implementations` setting is enabled, or the plaintext value otherwise.
+func implementationState(meta *providerMeta, implementation string) string {
+	if meta.HashImplementations && implementation != "" && !utils.IsHashedValue(implementation) {
+		return utils.HashImpl(implementation)
+	}
+	return implementation
 }
 
-// resourceIdToParts parses the last argument passed to the `terraform import sym_RESOURCE.RESOURCE_NAME RESOURCE_ID`
-// command into a resourceIdParts struct.
-//
-// The ``resource`` provided will be used for error message details.
-func resourceIdToParts(identifier, resource string) (*resourceIdParts, error) {
-	parts := strings.Split(identifier, ":")
+// implementationDiff returns a warning containing a compact unified diff between two implementations, which is
+// far easier to review than the full replacement Terraform displays. Nothing is returned if they are equivalent, or
+// if only the hash of the old implementation is known, since there is nothing to diff against.
+func implementationDiff(resource, old, new string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if utils.IsHashedValue(old) {
+		return diags
+	}
+
+	if diff := utils.ImplDiff(old, new); diff != "" {
+		diags = append(diags, utils.DiagWarning(fmt.Sprintf("%s implementation changed", resource), diff))
+	}
+
+	return diags
+}
 
-	if len(parts) != 2 {
-		return nil, utils.ErrInvalidImportTypeSlug(resource, identifier)
+func notFoundWarning(resource, id string) string {
+	return fmt.Sprintf("[WARN] Sym %s (%s) not found, removing from state", resource, id)
+}
+
+// importCandidate is an existing entity that a `terraform import` identifier may refer to.
+type importCandidate struct {
+	Id   string
+	Slug string
+
+	// Qualifiers are the values that may precede the slug in a `TYPE:SLUG` identifier, e.g. an
+	// Integration's type. The first is used when suggesting identifiers.
+	Qualifiers []string
+}
+
+// identifier returns the preferred import identifier for the candidate.
+func (c importCandidate) identifier() string {
+	if len(c.Qualifiers) > 0 {
+		return c.Qualifiers[0] + ":" + c.Slug
 	}
+	return c.Slug
+}
 
-	return &resourceIdParts{
-		Subtype: parts[0],
-		Slug:    parts[1],
-	}, nil
+// importCandidatesFunc lists every entity of a resource's type that may be imported.
+type importCandidatesFunc func(c *client.ApiClient) ([]importCandidate, error)
+
+// importCandidatesOf returns an importCandidatesFunc that lists entities with list and describes each one with
+// candidate.
+func importCandidatesOf[T any](list func(c *client.ApiClient) ([]T, error), candidate func(T) importCandidate) importCandidatesFunc {
+	return func(c *client.ApiClient) ([]importCandidate, error) {
+		entities, err := list(c)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]importCandidate, len(entities))
+		for i, entity := range entities {
+			candidates[i] = candidate(entity)
+		}
+		return candidates, nil
+	}
 }
 
-// getNameAndTypeImporter returns a function that may be used as a Terraform ResourceImporter. It should be used for any
-// resource which has a ReadContext method that supports fetching from the API with a slug and a sub_type_name. For
-// example, see "sym_integration" or "sym_target".
+// getImporter returns a function that may be used as a Terraform ResourceImporter for any resource.
 //
-// This Importer function sets nothing on the resource, and is instead a validator that checks whether the provided
-// ID is a valid lookup in the form of `TYPE:SLUG`. The ReadContext methods are responsible for re-parsing the ID
-// to know whether they are in the context of an import.
+// The identifier passed to `terraform import` (or an `import` block) may be the entity's UUID, `TYPE:SLUG`,
+// or `SLUG`. Identifiers other than UUIDs are resolved to the UUID of a matching entity from the list returned
+// by listCandidates, so that the ReadContext methods only ever need to read by UUID. If no entity matches,
+// the error suggests the most similar identifiers.
 //
 // The ``resource`` provided will be used for error message details.
-func getNameAndTypeImporter(resource string) func(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
-	return func(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
-		_, err := resourceIdToParts(data.Id(), resource)
+func getImporter(resource string, listCandidates importCandidatesFunc) schema.StateContextFunc {
+	return func(_ context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		id, err := importId(resource, data.Id(), meta.(*providerMeta).Client, listCandidates)
 		if err != nil {
 			return nil, err
 		}
-		return []*schema.ResourceData{data}, nil
+		data.SetId(id)
+
+		return importedResourceData(data)
+	}
+}
+
+// importId returns the UUID of the entity referred to by an import identifier. See getImporter.
+func importId(resource, identifier string, c *client.ApiClient, listCandidates importCandidatesFunc) (string, error) {
+	if _, err := uuid.ParseUUID(identifier); err == nil {
+		return identifier, nil
+	}
+
+	candidates, err := listCandidates(c)
+	if err != nil {
+		return "", err
+	}
+	return resolveImportIdentifier(resource, identifier, candidates)
+}
+
+// importedResourceData sets the defaults of attributes that are not read from Sym, so that
+// imported resources do not show a diff for them on the next plan.
+func importedResourceData(data *schema.ResourceData) ([]*schema.ResourceData, error) {
+	if err := data.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{data}, nil
+}
+
+// resolveImportIdentifier returns the UUID of the single candidate matching the given `TYPE:SLUG` or `SLUG`
+// identifier. Slugs and types are compared case-insensitively.
+func resolveImportIdentifier(resource, identifier string, candidates []importCandidate) (string, error) {
+	qualifier, slug := "", identifier
+	if parts := strings.SplitN(identifier, ":", 2); len(parts) == 2 {
+		qualifier, slug = parts[0], parts[1]
+	}
+
+	var matches []importCandidate
+	for _, c := range candidates {
+		// The whole identifier is checked as a slug as well, in case the slug itself contains a colon.
+		if strings.EqualFold(c.Slug, identifier) || (strings.EqualFold(c.Slug, slug) && containsFold(c.Qualifiers, qualifier)) {
+			matches = append(matches, c)
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0].Id, nil
+	case 0:
+		identifiers := make([]string, len(candidates))
+		for i, c := range candidates {
+			identifiers[i] = c.identifier()
+		}
+		return "", utils.ErrImportNotFound(resource, identifier, utils.SuggestSimilar(identifier, identifiers))
+	default:
+		identifiers := make([]string, len(matches))
+		for i, c := range matches {
+			identifiers[i] = c.identifier()
+		}
+		return "", utils.ErrImportAmbiguous(resource, identifier, identifiers)
+	}
+}
+
+func containsFold(slice []string, lookup string) bool {
+	for _, item := range slice {
+		if strings.EqualFold(item, lookup) {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/symopsio/terraform-provider-sym/sym/client"
+)
+
+func Test_resolveImportIdentifier(t *testing.T) {
+	candidates := []importCandidate{
+		{Id: "slack-id", Slug: "my-integration", Qualifiers: []string{"slack"}},
+		{Id: "okta-id", Slug: "my-integration", Qualifiers: []string{"okta"}},
+		{Id: "aws-id", Slug: "aws", Qualifiers: []string{"permission_context"}},
+		{Id: "secret-id", Slug: "/sym/secret", Qualifiers: []string{"aws", "source-id"}},
+	}
+
+	tests := []struct {
+		name       string
+		identifier string
+		wantId     string
+		wantErr    string
+	}{
+		{"type-and-slug", "slack:my-integration", "slack-id", ""},
+		{"type-and-slug-case-insensitive", "Slack:My-Integration", "slack-id", ""},
+		{"slug-only", "aws", "aws-id", ""},
+		{"secondary-qualifier", "source-id:/sym/secret", "secret-id", ""},
+		{"ambiguous-slug", "my-integration", "", "matches more than one integration. Please use one of: slack:my-integration, okta:my-integration"},
+		{"not-found-with-suggestion", "slack:my-integraton", "", "Did you mean slack:my-integration or okta:my-integration?"},
+		{"not-found-without-suggestion", "nonexistent-thing-entirely", "", "No integration matching the identifier nonexistent-thing-entirely could be found.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := resolveImportIdentifier("integration", tt.identifier, candidates)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantId, id)
+		})
+	}
+}
+
+func Test_importCandidatesOf(t *testing.T) {
+	candidates, err := importCandidatesOf(
+		func(c *client.ApiClient) ([]client.Target, error) {
+			return []client.Target{{Id: "target-id", Name: "prod", Type: "okta_group"}}, nil
+		},
+		func(target client.Target) importCandidate {
+			return importCandidate{Id: target.Id, Slug: target.Name, Qualifiers: []string{target.Type}}
+		},
+	)(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []importCandidate{{Id: "target-id", Slug: "prod", Qualifiers: []string{"okta_group"}}}, candidates)
+
+	_, err = importCandidatesOf(
+		func(c *client.ApiClient) ([]client.Target, error) { return nil, errors.New("list failed") },
+		func(target client.Target) importCandidate { return importCandidate{} },
+	)(nil)
+	assert.EqualError(t, err, "list failed")
+}
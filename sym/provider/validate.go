@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+// validateFunc asks the Sym API to validate the entity built from the plan of a resource.
+type validateFunc func(d *schema.ResourceDiff, m *providerMeta) error
+
+// validateOnPlan returns a CustomizeDiffFunc which, if the provider's `validate_on_plan` setting is enabled,
+// asks the Sym API to validate the entity the plan would create or update. Any entity the API would reject
+// is then reported during `terraform plan`, rather than part way through `terraform apply`.
+//
+// Validation is skipped if none of the given fields' attributes changed, or if any of them will not be
+// known until apply, e.g. because they refer to a resource which has not been created yet.
+func validateOnPlan(resource string, fields patchFields, validate validateFunc) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		m, ok := meta.(*providerMeta)
+		if !ok || !m.ValidateOnPlan {
+			return nil
+		}
+
+		var attributes []string
+		for _, fieldAttributes := range fields {
+			attributes = append(attributes, fieldAttributes...)
+		}
+
+		if d.Id() != "" && !d.HasChanges(attributes...) {
+			return nil
+		}
+		if unknown := unknownAttributes(d, attributes); len(unknown) > 0 {
+			log.Printf("Not validating %s during plan, as %s will not be known until apply", resource, strings.Join(unknown, ", "))
+			return nil
+		}
+
+		m, err := m.forOrg(d.Get("org").(string))
+		if err != nil {
+			return err
+		}
+
+		if err := validate(d, m); err != nil {
+			if utils.IsNotFoundError(err) {
+				log.Printf("Not validating %s during plan, as the Sym API does not support validating it", resource)
+				return nil
+			}
+			return fmt.Errorf("the Sym API rejected this %s: %w", resource, err)
+		}
+		return nil
+	}
+}
+
+// validateFrameworkPlan is the equivalent of validateOnPlan for framework resources, which call it from ModifyPlan.
+// validate is called with the providerMeta of the planned `org`.
+func validateFrameworkPlan(ctx context.Context, m *providerMeta, resource string, fields patchFields, plan tfsdk.Plan, state tfsdk.State, validate func(m *providerMeta) error) fwdiag.Diagnostics {
+	var diags fwdiag.Diagnostics
+	if !m.ValidateOnPlan {
+		return diags
+	}
+
+	var attributes []string
+	for _, fieldAttributes := range fields {
+		attributes = append(attributes, fieldAttributes...)
+	}
+
+	if !planChanges(plan, state)(attributes...) {
+		return diags
+	}
+	if unknown := unknownPlanAttributes(plan, attributes); len(unknown) > 0 {
+		log.Printf("Not validating %s during plan, as %s will not be known until apply", resource, strings.Join(unknown, ", "))
+		return diags
+	}
+
+	var org types.String
+	if diags.Append(plan.GetAttribute(ctx, path.Root("org"), &org)...); diags.HasError() {
+		return diags
+	}
+	m, err := m.forOrg(org.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("org"), "Invalid org", err.Error())
+		return diags
+	}
+
+	if err := validate(m); err != nil {
+		if utils.IsNotFoundError(err) {
+			log.Printf("Not validating %s during plan, as the Sym API does not support validating it", resource)
+			return diags
+		}
+		diags.AddError(fmt.Sprintf("The Sym API rejected this %s", resource), err.Error())
+	}
+	return diags
+}
+
+// unknownAttributes returns those of the given attributes whose planned values are not yet wholly known.
+func unknownAttributes(d *schema.ResourceDiff, attributes []string) []string {
+	var unknown []string
+	rawConfig := d.GetRawConfig()
+
+	for _, attribute := range attributes {
+		known := d.NewValueKnown(attribute)
+
+		// Values nested in a map or list may be unknown even if the map or list itself is known.
+		if known && !rawConfig.IsNull() && rawConfig.Type().IsObjectType() && rawConfig.Type().HasAttribute(attribute) {
+			known = rawConfig.GetAttr(attribute).IsWhollyKnown()
+		}
+
+		if !known {
+			unknown = append(unknown, attribute)
+		}
+	}
+	return unknown
+}
+
+// diagsError returns the errors in the diagnostics as a single error, or nil if there are none.
+func diagsError(diags diag.Diagnostics) error {
+	var messages []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			messages = append(messages, fmt.Sprintf("%s: %s", d.Summary, d.Detail))
+		}
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// frameworkDiagsError returns the errors in framework diagnostics as a single error. See diagsError.
+func frameworkDiagsError(diags fwdiag.Diagnostics) error {
+	var messages []string
+	for _, d := range diags.Errors() {
+		messages = append(messages, fmt.Sprintf("%s: %s", d.Summary(), d.Detail()))
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
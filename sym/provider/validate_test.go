@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/symopsio/terraform-provider-sym/sym/utils"
+)
+
+func Test_validateOnPlan(t *testing.T) {
+	const unknown = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+	tests := []struct {
+		name          string
+		enabled       bool
+		state         *terraform.InstanceState
+		config        map[string]interface{}
+		validateErr   error
+		wantValidated bool
+		wantErr       string
+	}{
+		{"disabled", false, nil, map[string]interface{}{"name": "prod"}, nil, false, ""},
+		{"valid", true, nil, map[string]interface{}{"name": "prod"}, nil, true, ""},
+		{
+			"rejected",
+			true,
+			nil,
+			map[string]interface{}{"name": "prod"},
+			errors.New("boom"),
+			true,
+			"the Sym API rejected this Target: boom",
+		},
+		{
+			"not-supported",
+			true,
+			nil,
+			map[string]interface{}{"name": "prod"},
+			utils.ErrAPINotFound("/entities/access-targets/validate", "request-id"),
+			true,
+			"",
+		},
+		{"unknown", true, nil, map[string]interface{}{"name": unknown}, nil, false, ""},
+		{
+			"unchanged",
+			true,
+			&terraform.InstanceState{ID: "target-id", Attributes: map[string]string{"id": "target-id", "name": "prod"}},
+			map[string]interface{}{"name": "prod", "label": "Prod"},
+			nil,
+			false,
+			"",
+		},
+		{
+			"changed",
+			true,
+			&terraform.InstanceState{ID: "target-id", Attributes: map[string]string{"id": "target-id", "name": "prod"}},
+			map[string]interface{}{"name": "staging"},
+			nil,
+			true,
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validated := false
+			validate := func(d *schema.ResourceDiff, _ *providerMeta) error {
+				validated = true
+				assert.Equal(t, tt.config["name"], d.Get("name"))
+				return tt.validateErr
+			}
+
+			r := &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name":  utils.Required(schema.TypeString, "The name."),
+					"label": utils.Optional(schema.TypeString, "The label."),
+					"org":   utils.Optional(schema.TypeString, "The org."),
+				},
+				CustomizeDiff: validateOnPlan("Target", patchFields{"slug": {"name"}}, validate),
+			}
+			meta := &providerMeta{ValidateOnPlan: tt.enabled}
+
+			_, err := r.Diff(context.Background(), tt.state, terraform.NewResourceConfigRaw(tt.config), meta)
+
+			assert.Equal(t, tt.wantValidated, validated)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_diagsError(t *testing.T) {
+	tests := []struct {
+		name  string
+		diags diag.Diagnostics
+		want  string
+	}{
+		{"none", nil, ""},
+		{"warnings-only", diag.Diagnostics{utils.DiagWarning("careful", "a warning")}, ""},
+		{
+			"errors",
+			diag.Diagnostics{
+				utils.DiagWarning("careful", "a warning"),
+				utils.DiagFromError(errors.New("bad vars"), "Invalid Flow"),
+				utils.DiagFromError(errors.New("bad params"), "Invalid Flow"),
+			},
+			"Invalid Flow: bad vars; Invalid Flow: bad params",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := diagsError(tt.diags)
+			if tt.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.want)
+			}
+		})
+	}
+}
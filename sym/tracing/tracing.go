@@ -0,0 +1,127 @@
+// Package tracing configures optional OpenTelemetry tracing for the provider.
+//
+// When tracing is not configured, the global no-op TracerProvider is used, so spans
+// created by the provider and client packages cost next to nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName  = "github.com/symopsio/terraform-provider-sym"
+	serviceName = "terraform-provider-sym"
+)
+
+// Attribute keys recorded on the provider's spans.
+const (
+	ResourceTypeKey = attribute.Key("sym.resource_type")
+	ResourceIDKey   = attribute.Key("sym.resource_id")
+	SlugKey         = attribute.Key("sym.slug")
+	RequestIDKey    = attribute.Key("sym.request_id")
+)
+
+// Config describes where spans should be exported to. If both fields are set,
+// spans are exported to both destinations.
+type Config struct {
+	// OTLPEndpoint is the URL of an OTLP/HTTP collector, e.g. "http://localhost:4318".
+	OTLPEndpoint string
+
+	// FilePath is a local file that spans will be appended to as newline-delimited JSON.
+	FilePath string
+}
+
+var (
+	mu             sync.Mutex
+	tracerProvider *sdktrace.TracerProvider
+	traceFile      *os.File
+)
+
+// Setup installs a global TracerProvider exporting spans as described by the given Config,
+// and configures W3C Trace Context propagation so traces continue into the Sym API.
+//
+// Terraform may configure the provider more than once in a single process, so only the
+// first call with a non-empty Config has any effect.
+func Setup(ctx context.Context, cfg Config) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if tracerProvider != nil || (cfg.OTLPEndpoint == "" && cfg.FilePath == "") {
+		return nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return err
+	}
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+
+	if cfg.OTLPEndpoint != "" {
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		if err != nil {
+			return fmt.Errorf("unable to create OTLP trace exporter for %s: %w", cfg.OTLPEndpoint, err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	if cfg.FilePath != "" {
+		f, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("unable to open trace file %s: %w", cfg.FilePath, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return fmt.Errorf("unable to create file trace exporter for %s: %w", cfg.FilePath, err)
+		}
+		// Spans are written synchronously so that nothing is lost if Terraform kills the provider process.
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+		traceFile = f
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return nil
+}
+
+// Shutdown flushes any buffered spans and releases the exporters. It is a no-op if
+// tracing was never set up.
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if tracerProvider == nil {
+		return nil
+	}
+
+	err := tracerProvider.Shutdown(ctx)
+	if traceFile != nil {
+		if closeErr := traceFile.Close(); err == nil {
+			err = closeErr
+		}
+		traceFile = nil
+	}
+	tracerProvider = nil
+
+	return err
+}
+
+// Tracer returns the Tracer used for every span created by the provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
@@ -128,3 +128,24 @@ func SuppressEquivalentFileContentDiffs(k string, old string, new string, _ *sch
 func SuppressCaseSensitiveNamesDiffs(k, old, new string, _ *schema.ResourceData) bool {
 	return strings.EqualFold(old, new)
 }
+
+// SuppressHashedSettingsDiffs is a DiffSuppressFunc for settings maps whose values are
+// stored in the Terraform state as hashes rather than plaintext (e.g. `sensitive_settings`).
+//
+// The state holds the output of HashSensitiveValue, while the config holds the plaintext
+// value, so the two are considered equal if the config value hashes to the value in state.
+func SuppressHashedSettingsDiffs(k, old, new string, _ *schema.ResourceData) bool {
+	return old == new || (IsHashedValue(old) && old == HashSensitiveValue(new))
+}
+
+// SuppressEquivalentImplDiffs is a DiffSuppressFunc for Python implementations that ignores
+// differences in line endings, trailing whitespace, and final newlines. See NormalizeImpl.
+//
+// If the implementation is stored in the Terraform state as a hash (see HashImpl), the config
+// value is considered equal if it hashes to the value in state.
+func SuppressEquivalentImplDiffs(k, old, new string, _ *schema.ResourceData) bool {
+	if IsHashedValue(old) {
+		return old == HashImpl(new)
+	}
+	return NormalizeImpl(old) == NormalizeImpl(new)
+}
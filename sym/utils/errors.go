@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 )
 
 type Error struct {
@@ -54,6 +55,11 @@ var ErrAPINotFound = func(endpoint string, requestId string) error {
 	return GenerateError(errorMessage, DocsSupport)
 }
 
+// IsNotFoundError reports whether err is an ErrAPINotFound returned by the Sym API.
+func IsNotFoundError(err error) bool {
+	return strings.Contains(err.Error(), "\nStatus Code: 404\n")
+}
+
 var ErrAPIConnect = func(endpoint string, requestId string) error {
 	errorMessage := fmt.Sprintf("An unexpected error occurred while connecting to the Sym API. Please reach out to Sym Support.\nURL: %s\nRequest ID: %s", endpoint, requestId)
 	return GenerateError(errorMessage, DocsSupport)
@@ -64,17 +70,35 @@ var ErrAPIUnexpected = func(endpoint string, requestId string, statusCode int) e
 	return GenerateError(errorMessage, DocsSupport)
 }
 
+var ErrAPIPreconditionFailed = func(endpoint string, requestId string) error {
+	errorMessage := fmt.Sprintf("The Sym entity was changed since it was last read by Terraform, so it was not modified. Please re-run `terraform plan` to review the change before applying.\nURL: %s\nStatus Code: 412\nRequest ID: %s", endpoint, requestId)
+	return GenerateError(errorMessage, DocsHome)
+}
+
 var ErrAPIBadRequest = func(messages []Error) error {
 	errorMessage := fmt.Sprintf("The Sym API returned a bad request error: %v", messages)
 	return GenerateError(errorMessage, DocsSupport)
 }
 
-var ErrInvalidImportTypeSlug = func(resource, identifier string) error {
-	errorMessage := fmt.Sprintf("The provided %s identifier %s could not be parsed. Identifiers must be in the form of `TYPE:SLUG`.", resource, identifier)
+var ErrFlowsFilterExists = GenerateError(
+	"A FlowsFilter already exists in this org. Set `adopt_existing = true` to manage the existing FlowsFilter with this resource, or import it with `terraform import`.",
+	DocsImport,
+)
+
+var ErrFlowsFilterOwned = func(owner, configuredOwner string) error {
+	errorMessage := fmt.Sprintf("The FlowsFilter is owned by %q, but this sym_flows_filter's owner is %q. Only its owner may modify or delete the FlowsFilter.", owner, configuredOwner)
+	return GenerateError(errorMessage, DocsHome)
+}
+
+var ErrImportNotFound = func(resource, identifier string, suggestions []string) error {
+	errorMessage := fmt.Sprintf("No %s matching the identifier %s could be found.", resource, identifier)
+	if len(suggestions) > 0 {
+		errorMessage += fmt.Sprintf(" Did you mean %s?", strings.Join(suggestions, " or "))
+	}
 	return GenerateError(errorMessage, DocsImport)
 }
 
-var ErrInvalidImportUUID = func(resource string) error {
-	errorMessage := fmt.Sprintf("Looks like you tried to import a %s by UUID, but it only supports importing by `SLUG`.", resource)
+var ErrImportAmbiguous = func(resource, identifier string, matches []string) error {
+	errorMessage := fmt.Sprintf("The %s identifier %s matches more than one %s. Please use one of: %s", resource, identifier, resource, strings.Join(matches, ", "))
 	return GenerateError(errorMessage, DocsImport)
 }
@@ -0,0 +1,14 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	assert.True(t, IsNotFoundError(ErrAPINotFound("/entities/flows-filter", "request-id")))
+	assert.False(t, IsNotFoundError(ErrAPIConnect("/entities/flows-filter", "request-id")))
+	assert.False(t, IsNotFoundError(errors.New("boom")))
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the maximum number of suggestions returned by SuggestSimilar.
+const maxSuggestions = 3
+
+// LevenshteinDistance returns the minimum number of single-character insertions, deletions,
+// and substitutions required to change one string into the other.
+func LevenshteinDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, minInt(current[j-1]+1, previous[j-1]+cost))
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(br)]
+}
+
+// SuggestSimilar returns up to three of the candidates that are most similar to the lookup string,
+// ignoring case, ordered from most to least similar. A candidate is considered similar if it contains
+// the lookup string (or vice versa), or is within a small edit distance of it.
+func SuggestSimilar(lookup string, candidates []string) []string {
+	type suggestion struct {
+		value    string
+		distance int
+	}
+
+	lookup = strings.ToLower(lookup)
+	maxDistance := len(lookup)/3 + 1
+
+	var suggestions []suggestion
+	for _, candidate := range candidates {
+		lower := strings.ToLower(candidate)
+		distance := LevenshteinDistance(lookup, lower)
+		if distance <= maxDistance || strings.Contains(lower, lookup) || strings.Contains(lookup, lower) {
+			suggestions = append(suggestions, suggestion{candidate, distance})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].distance < suggestions[j].distance
+	})
+
+	var result []string
+	for i := 0; i < len(suggestions) && i < maxSuggestions; i++ {
+		result = append(result, suggestions[i].value)
+	}
+	return result
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
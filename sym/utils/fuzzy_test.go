@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"equal", "slack", "slack", 0},
+		{"empty", "", "slack", 5},
+		{"substitution", "slack", "slick", 1},
+		{"insertion", "my-integration", "my-integrations", 1},
+		{"deletion", "prod", "pod", 1},
+		{"kitten", "kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LevenshteinDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("LevenshteinDistance() = %v, want %v", got, tt.want)
+			}
+			if got := LevenshteinDistance(tt.b, tt.a); got != tt.want {
+				t.Errorf("LevenshteinDistance() is not symmetric, got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestSimilar(t *testing.T) {
+	candidates := []string{"slack:my-integration", "slack:other", "aws:my-integration", "pagerduty:on-call"}
+
+	tests := []struct {
+		name   string
+		lookup string
+		want   []string
+	}{
+		{"typo", "slack:my-integraton", []string{"slack:my-integration", "aws:my-integration"}},
+		{"wrong-case", "SLACK:MY-INTEGRATION", []string{"slack:my-integration", "aws:my-integration"}},
+		{"missing-type", "my-integration", []string{"aws:my-integration", "slack:my-integration"}},
+		{"no-match", "okta:everyone", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuggestSimilar(tt.lookup, candidates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuggestSimilar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
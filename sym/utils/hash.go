@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// HashPrefix is prepended to every hash the provider stores in the Terraform state, so that
+// hashed values can be told apart from the plaintext values they replace.
+const HashPrefix = "sha256:"
+
+// HashSensitiveValue returns the SHA-256 hash of the given value in the form stored in
+// the Terraform state, e.g. "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae".
+func HashSensitiveValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return HashPrefix + hex.EncodeToString(sum[:])
+}
+
+// IsHashedValue returns true if the given value was produced by HashSensitiveValue.
+func IsHashedValue(value string) bool {
+	return strings.HasPrefix(value, HashPrefix) && len(value) == len(HashPrefix)+sha256.Size*2
+}
+
+// HashSettings returns a copy of the given settings map with every value replaced by its hash.
+func HashSettings(settings map[string]string) map[string]string {
+	hashed := make(map[string]string, len(settings))
+	for k, v := range settings {
+		hashed[k] = HashSensitiveValue(v)
+	}
+	return hashed
+}
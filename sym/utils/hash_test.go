@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestHashSensitiveValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			"simple",
+			"foo",
+			"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		},
+		{
+			"empty",
+			"",
+			"sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HashSensitiveValue(tt.value)
+			if got != tt.want {
+				t.Errorf("HashSensitiveValue() = %v, want %v", got, tt.want)
+			}
+			if !IsHashedValue(got) {
+				t.Errorf("IsHashedValue(%v) = false, want true", got)
+			}
+		})
+	}
+}
+
+func TestIsHashedValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"hashed", HashSensitiveValue("my-token"), true},
+		{"plaintext", "my-token", false},
+		{"prefix-only", "sha256:my-token", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsHashedValue(tt.value); got != tt.want {
+				t.Errorf("IsHashedValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuppressHashedSettingsDiffs(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want bool
+	}{
+		{"same-hash", HashSensitiveValue("token"), "token", true},
+		{"different-hash", HashSensitiveValue("token"), "other-token", false},
+		{"same-count", "2", "2", true},
+		{"different-count", "2", "3", false},
+		{"new-key", "", "token", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuppressHashedSettingsDiffs("sensitive_settings.key", tt.old, tt.new, nil); got != tt.want {
+				t.Errorf("SuppressHashedSettingsDiffs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
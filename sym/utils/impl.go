@@ -2,9 +2,16 @@ package utils
 
 import (
 	"encoding/base64"
+	"fmt"
 	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
 )
 
+// maxImplDiffLines is the maximum number of lines of a diff returned by ImplDiff.
+const maxImplDiffLines = 100
+
 // ParseImpl takes in an impl in base64, text, or filename format,
 // and consistently returns the impl in text format, if possible.
 func ParseImpl(impl string) string {
@@ -34,3 +41,77 @@ func parseImpl(impl string, isFilePath bool) string {
 	}
 	return string(contents)
 }
+
+// NormalizeImpl returns the given implementation with differences that don't change its meaning
+// removed, so that e.g. a Windows checkout of the same file does not produce a diff:
+//
+//   - CRLF and CR line endings are converted to LF
+//   - trailing whitespace is removed from each line
+//   - trailing blank lines are removed, and a non-empty implementation ends with exactly one newline
+func NormalizeImpl(impl string) string {
+	impl = strings.ReplaceAll(impl, "\r\n", "\n")
+	impl = strings.ReplaceAll(impl, "\r", "\n")
+
+	lines := strings.Split(impl, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	impl = strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if impl == "" {
+		return ""
+	}
+	return impl + "\n"
+}
+
+// BundleImpls concatenates the given implementations in order, each normalized with NormalizeImpl and
+// separated by two blank lines as PEP 8 separates top-level definitions. Empty implementations are skipped.
+func BundleImpls(impls ...string) string {
+	var bundle []string
+	for _, impl := range impls {
+		if normalized := NormalizeImpl(impl); normalized != "" {
+			bundle = append(bundle, normalized)
+		}
+	}
+	return strings.Join(bundle, "\n\n")
+}
+
+// NormalizeImplStateFunc is a StateFunc that stores implementations normalized with NormalizeImpl.
+func NormalizeImplStateFunc(val interface{}) string {
+	return NormalizeImpl(val.(string))
+}
+
+// HashImpl returns the hash of the given implementation after normalizing it with NormalizeImpl,
+// for implementations stored in the Terraform state as hashes. See HashSensitiveValue.
+func HashImpl(impl string) string {
+	return HashSensitiveValue(NormalizeImpl(impl))
+}
+
+// ImplDiff returns a compact unified diff between two implementations after normalizing them
+// with NormalizeImpl, or an empty string if they are equivalent. Diffs longer than
+// maxImplDiffLines lines are truncated.
+func ImplDiff(old, new string) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitImplLines(old),
+		B:        splitImplLines(new),
+		FromFile: "current",
+		ToFile:   "planned",
+		Context:  3,
+	})
+	if err != nil || diff == "" {
+		return ""
+	}
+
+	lines := strings.SplitAfter(strings.TrimRight(diff, "\n"), "\n")
+	if len(lines) > maxImplDiffLines {
+		lines = append(lines[:maxImplDiffLines], fmt.Sprintf("\n... (%d more lines)", len(lines)-maxImplDiffLines))
+	}
+	return strings.Join(lines, "")
+}
+
+// splitImplLines normalizes an implementation and splits it into lines, each keeping its newline.
+func splitImplLines(impl string) []string {
+	lines := strings.SplitAfter(NormalizeImpl(impl), "\n")
+	// A normalized implementation always ends with a newline, so the last element is always empty.
+	return lines[:len(lines)-1]
+}
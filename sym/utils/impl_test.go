@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeImpl(t *testing.T) {
+	tests := []struct {
+		name string
+		impl string
+		want string
+	}{
+		{
+			"already-normalized",
+			"from sym.sdk.annotations import reducer\n\n@reducer\ndef get_approvers(event):\n    pass\n",
+			"from sym.sdk.annotations import reducer\n\n@reducer\ndef get_approvers(event):\n    pass\n",
+		},
+		{
+			"crlf-line-endings",
+			"import foo\r\n\r\ndef bar():\r\n    pass\r\n",
+			"import foo\n\ndef bar():\n    pass\n",
+		},
+		{
+			"cr-line-endings",
+			"import foo\rimport bar\r",
+			"import foo\nimport bar\n",
+		},
+		{
+			"trailing-whitespace",
+			"import foo   \n\t\ndef bar():\t\n    pass \n",
+			"import foo\n\ndef bar():\n    pass\n",
+		},
+		{
+			"missing-final-newline",
+			"import foo",
+			"import foo\n",
+		},
+		{
+			"extra-final-newlines",
+			"import foo\n\n\n  \n",
+			"import foo\n",
+		},
+		{
+			"leading-whitespace-is-kept",
+			"\n    indented\n",
+			"\n    indented\n",
+		},
+		{
+			"empty",
+			"\r\n \n",
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeImpl(tt.impl); got != tt.want {
+				t.Errorf("NormalizeImpl() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBundleImpls(t *testing.T) {
+	tests := []struct {
+		name  string
+		impls []string
+		want  string
+	}{
+		{"single", []string{"import foo"}, "import foo\n"},
+		{
+			"helpers-first",
+			[]string{"def helper():\r\n    pass\r\n", "@reducer\ndef get_approvers(event):\n    return helper()\n\n\n"},
+			"def helper():\n    pass\n\n\n@reducer\ndef get_approvers(event):\n    return helper()\n",
+		},
+		{"skips-empty", []string{"", "import foo\n", " \n"}, "import foo\n"},
+		{"none", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BundleImpls(tt.impls...); got != tt.want {
+				t.Errorf("BundleImpls() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImplDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{
+			"equivalent",
+			"import foo\r\n",
+			"import foo   \n\n",
+			"",
+		},
+		{
+			"changed-line",
+			"import foo\n\ndef bar():\n    return 1\n",
+			"import foo\n\ndef bar():\n    return 2\n",
+			"--- current\n+++ planned\n@@ -1,4 +1,4 @@\n import foo\n \n def bar():\n-    return 1\n+    return 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ImplDiff(tt.old, tt.new); got != tt.want {
+				t.Errorf("ImplDiff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImplDiff_truncated(t *testing.T) {
+	old := strings.Repeat("a\n", 200)
+	new := strings.Repeat("b\n", 200)
+
+	got := ImplDiff(old, new)
+	if lines := strings.Count(got, "\n"); lines != maxImplDiffLines+1 {
+		t.Errorf("ImplDiff() returned %d lines, want %d", lines, maxImplDiffLines+1)
+	}
+	if !strings.HasSuffix(got, "... (303 more lines)") {
+		t.Errorf("ImplDiff() = %q, want a truncation message", got[len(got)-40:])
+	}
+}
+
+func TestSuppressEquivalentImplDiffs(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want bool
+	}{
+		{"equal", "import foo\n", "import foo\n", true},
+		{"equivalent", "import foo\r\n", "import foo  \n\n", true},
+		{"different", "import foo\n", "import bar\n", false},
+		{"hashed-equivalent", HashImpl("import foo\n"), "import foo\r\n", true},
+		{"hashed-different", HashImpl("import foo\n"), "import bar\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SuppressEquivalentImplDiffs("implementation", tt.old, tt.new, nil); got != tt.want {
+				t.Errorf("SuppressEquivalentImplDiffs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -53,3 +56,58 @@ func StringList(required bool, description string) *schema.Schema {
 		Description: description,
 	}
 }
+
+// StringSet returns the schema for a set of strings, such as IDs, whose order has no meaning.
+func StringSet(required bool, description string) *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeSet,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Required:    required,
+		Optional:    !required,
+		Description: description,
+	}
+}
+
+// SetStrings returns the strings in a set of strings, sorted so that they are always sent to Sym in the same order.
+func SetStrings(set *schema.Set) []string {
+	var values []string
+	for _, v := range set.List() {
+		values = append(values, v.(string))
+	}
+	sort.Strings(values)
+	return values
+}
+
+// SensitiveSettingsMap returns the schema for a map of settings whose values must never be
+// displayed in plan output or stored in plaintext in the Terraform state. Values are stored
+// as hashes, which are compared against the configured values to detect changes. The settings are
+// described as specific to subject, e.g. "this type of Integration".
+func SensitiveSettingsMap(subject string) *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeMap,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Optional:         true,
+		Sensitive:        true,
+		DiffSuppressFunc: SuppressHashedSettingsDiffs,
+		Description: fmt.Sprintf("A map of settings specific to %s whose values should not be displayed in plan output "+
+			"or stored in the Terraform state. These are merged into `settings` when sent to Sym, and only hashes of "+
+			"the values are stored in the state.", subject),
+	}
+}
+
+// DeletionProtectionDescription is the description of the `deletion_protection` attribute shared by all resources.
+const DeletionProtectionDescription = "Whether Terraform will be prevented from deleting this resource. Set this to `false` and apply before destroying or replacing the resource."
+
+// DeletionProtection returns the schema for the `deletion_protection` attribute shared by all resources.
+func DeletionProtection() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: DeletionProtectionDescription,
+	}
+}
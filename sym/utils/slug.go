@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// invalidSlugChars matches runs of characters which are not allowed in Sym slugs.
+	invalidSlugChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+	// repeatedSlugDashes matches runs of dashes, which Sym collapses into one.
+	repeatedSlugDashes = regexp.MustCompile(`-{2,}`)
+)
+
+// Slugify converts the given text to a slug following the Sym API's rules for entity names: slugs are
+// lowercase, contain only letters, numbers, underscores, and dashes, and neither start nor end with a
+// dash or underscore. Every run of other characters is replaced by a single dash, e.g.
+// "AWS  Prod / Admins" becomes "aws-prod-admins".
+func Slugify(text string) string {
+	slug := invalidSlugChars.ReplaceAllString(strings.ToLower(text), "-")
+	slug = repeatedSlugDashes.ReplaceAllString(slug, "-")
+	return strings.Trim(slug, "-_")
+}
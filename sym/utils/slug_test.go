@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"already-a-slug", "aws_prod-admins", "aws_prod-admins"},
+		{"uppercase", "AWSProd", "awsprod"},
+		{"spaces-and-punctuation", "AWS  Prod / Admins", "aws-prod-admins"},
+		{"repeated-dashes", "prod--admins", "prod-admins"},
+		{"trimmed", " _-Prod Admins!- ", "prod-admins"},
+		{"non-ascii", "Café Ops", "caf-ops"},
+		{"empty", "!!!", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slugify(tt.text); got != tt.want {
+				t.Errorf("Slugify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// srnSeparator separates the components of an SRN.
+const srnSeparator = ":"
+
+var (
+	// srnComponentPattern matches the org, model, slug, and identifier components of an SRN.
+	srnComponentPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+	// srnVersionPattern matches the version component of an SRN, which is either "latest" or a semantic version.
+	srnVersionPattern = regexp.MustCompile(`^(latest|[0-9]+\.[0-9]+\.[0-9]+)$`)
+)
+
+// SRN is a Sym Resource Name, which identifies a versioned Sym entity, e.g. "sym:template:approval:1.0.0".
+// The format is `<org>:<model>:<slug>:<version>[:<identifier>]`.
+type SRN struct {
+	Org        string
+	Model      string
+	Slug       string
+	Version    string
+	Identifier string
+}
+
+// ParseSRN parses and validates the given SRN.
+func ParseSRN(srn string) (SRN, error) {
+	parts := strings.Split(srn, srnSeparator)
+	if len(parts) < 4 || len(parts) > 5 {
+		return SRN{}, fmt.Errorf("%q is not a valid SRN: expected 4 or 5 components in the format <org>:<model>:<slug>:<version>[:<identifier>], got %d", srn, len(parts))
+	}
+
+	parsed := SRN{Org: parts[0], Model: parts[1], Slug: parts[2], Version: parts[3]}
+	if len(parts) == 5 {
+		parsed.Identifier = parts[4]
+	}
+	if err := parsed.Validate(); err != nil {
+		return SRN{}, fmt.Errorf("%q is not a valid SRN: %w", srn, err)
+	}
+	return parsed, nil
+}
+
+// Validate returns an error describing the first invalid component of the SRN, if any.
+// The identifier is optional, and every other component is required.
+func (s SRN) Validate() error {
+	components := []struct {
+		name  string
+		value string
+	}{
+		{"org", s.Org},
+		{"model", s.Model},
+		{"slug", s.Slug},
+	}
+	for _, c := range components {
+		if !srnComponentPattern.MatchString(c.value) {
+			return fmt.Errorf("%s %q must be non-empty and contain only letters, numbers, underscores, and dashes", c.name, c.value)
+		}
+	}
+
+	if !srnVersionPattern.MatchString(s.Version) {
+		return fmt.Errorf("version %q must be \"latest\" or a semantic version like \"1.0.0\"", s.Version)
+	}
+	if s.Identifier != "" && !srnComponentPattern.MatchString(s.Identifier) {
+		return fmt.Errorf("identifier %q must contain only letters, numbers, underscores, and dashes", s.Identifier)
+	}
+	return nil
+}
+
+func (s SRN) String() string {
+	parts := []string{s.Org, s.Model, s.Slug, s.Version}
+	if s.Identifier != "" {
+		parts = append(parts, s.Identifier)
+	}
+	return strings.Join(parts, srnSeparator)
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSRN(t *testing.T) {
+	tests := []struct {
+		name    string
+		srn     string
+		want    SRN
+		wantErr string
+	}{
+		{
+			"template",
+			"sym:template:approval:1.0.0",
+			SRN{Org: "sym", Model: "template", Slug: "approval", Version: "1.0.0"},
+			"",
+		},
+		{
+			"identifier",
+			"healthy-health:flow:access_request:latest:b0a7c3e2",
+			SRN{Org: "healthy-health", Model: "flow", Slug: "access_request", Version: "latest", Identifier: "b0a7c3e2"},
+			"",
+		},
+		{"too-few-components", "sym:template:approval", SRN{}, "expected 4 or 5 components"},
+		{"too-many-components", "sym:template:approval:1.0.0:a:b", SRN{}, "expected 4 or 5 components"},
+		{"empty-slug", "sym:template::1.0.0", SRN{}, `slug "" must be non-empty`},
+		{"invalid-org", "sym inc:template:approval:1.0.0", SRN{}, `org "sym inc"`},
+		{"invalid-version", "sym:template:approval:v1", SRN{}, `version "v1"`},
+		{"invalid-identifier", "sym:template:approval:1.0.0:a.b", SRN{}, `identifier "a.b"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSRN(tt.srn)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.srn, got.String())
+		})
+	}
+}